@@ -0,0 +1,263 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrHelp is returned by ParseArgs when -h or --help is given and no field
+// claims that flag, so the caller can print usage and exit.
+var ErrHelp = errors.New("help requested")
+
+// ErrUnknownFlag is returned by ParseArgs for a flag no field declares.
+var ErrUnknownFlag = errors.New("unknown flag")
+
+// ErrMissingFlagValue is returned by ParseArgs when a non-boolean flag is the
+// last argument and has no value.
+var ErrMissingFlagValue = errors.New("missing flag value")
+
+// cliFlag is one command-line flag, described by a field's `arg:` (long
+// name) and `short:` tags. Nested fields use their FQN arg tag, so a field
+// under `arg:"database"` is --database.dsn.
+type cliFlag struct {
+	name  string
+	short string
+	// isBool flags take no value: --verbose is true, --no-verbose false.
+	isBool bool
+	// repeated flags (slice, array and map fields) collect every occurrence into a
+	// MultiValue, e.g. --label env=prod --label tier=db.
+	repeated bool
+}
+
+// key is the inputs map key the flag's value is stored under: the long name,
+// or the short one for a short-only flag. Both are matched by DefaultCLITags.
+func (f cliFlag) key() string {
+	if f.name != "" {
+		return f.name
+	}
+	return f.short
+}
+
+// getFlags collects the flags declared by fields and their nested fields, in
+// declaration order. Fields without an arg or short tag are not flags.
+func getFlags(fields []Field, settings Settings) []cliFlag {
+	flags := make([]cliFlag, 0)
+	for _, field := range fields {
+		named := field
+		if field.FQN != nil {
+			named = *field.FQN
+		}
+
+		if field.Fields == nil && (named.Tags[argTag] != "" || named.Tags[shortTag] != "") {
+			flags = append(flags, cliFlag{
+				name:     named.Tags[argTag],
+				short:    field.Tags[shortTag],
+				isBool:   isBoolField(field),
+				repeated: isRepeatedField(field, settings),
+			})
+		}
+
+		if field.Fields != nil {
+			flags = append(flags, getFlags(field.Fields, settings)...)
+		}
+	}
+	return flags
+}
+
+// isRepeatedField reports whether field's flag collects its occurrences: a
+// slice, array or map field, unless its type is set as a whole from one
+// value, through an unmarshaler (net.IP) or a registered converter.
+func isRepeatedField(field Field, settings Settings) bool {
+	if field.Kind != reflect.Slice && field.Kind != reflect.Array && field.Kind != reflect.Map {
+		return false
+	}
+	if !field.Value.IsValid() {
+		return true
+	}
+	return !hasUnmarshaler(field.Value.Type()) && !settings.hasConverter(field.Value.Type())
+}
+
+func isBoolField(field Field) bool {
+	if !field.Value.IsValid() {
+		return field.Kind == reflect.Bool
+	}
+	typ := field.Value.Type()
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Bool
+}
+
+// ParseArgs parses command-line arguments (os.Args[1:]) against the flags
+// declared by structure's `arg:` and `short:` tags and returns the inputs map
+// for Set, keyed by each flag's arg tag (or short tag for a short-only flag),
+// plus the positional arguments. Pair it with DefaultCLITags.
+//
+// Supported forms: --long=value, --long value, -s value, -s=value, -svalue,
+// bundled short booleans (-vq), --no-long to set a boolean false, and "--" to
+// end flag parsing. A boolean flag given alone is true. A flag for a slice
+// field may repeat; its occurrences are collected into a MultiValue, each still
+// split on the field's sep tag. Repeating any other flag, including one for a
+// slice type that unmarshals itself such as net.IP, keeps the last value.
+// Defaults are not added to the map; Set applies them.
+func ParseArgs(structure any, args []string) (map[string]any, []string, error) {
+	return parseArgs(structure, Settings{}, args)
+}
+
+// parseArgs is ParseArgs with the settings whose converters decide which
+// slice flags repeat.
+func parseArgs(structure any, settings Settings, args []string) (map[string]any, []string, error) {
+	fields, err := GetStructFields(structure, nil, DefaultEncodingTags)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return parseFlags(getFlags(fields, settings), args)
+}
+
+func parseFlags(flags []cliFlag, args []string) (map[string]any, []string, error) {
+	byName := make(map[string]cliFlag)
+	byShort := make(map[string]cliFlag)
+	for _, f := range flags {
+		if f.name != "" {
+			byName[f.name] = f
+		}
+		if f.short != "" {
+			byShort[f.short] = f
+		}
+	}
+
+	inputs := make(map[string]any)
+	positional := make([]string, 0)
+
+	store := func(f cliFlag, value any) {
+		if !f.repeated {
+			inputs[f.key()] = value
+			return
+		}
+		multi, _ := inputs[f.key()].(MultiValue)
+		inputs[f.key()] = append(multi, fmt.Sprint(value))
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		switch {
+		case arg == "--":
+			positional = append(positional, args[i+1:]...)
+			return inputs, positional, nil
+		case strings.HasPrefix(arg, "--"):
+			name, value, hasValue := strings.Cut(arg[2:], "=")
+			f, ok := byName[name]
+			if !ok {
+				negated, isNegated := strings.CutPrefix(name, "no-")
+				if nf, ok := byName[negated]; isNegated && ok && nf.isBool && !hasValue {
+					store(nf, false)
+					continue
+				}
+				if name == "help" {
+					return nil, nil, ErrHelp
+				}
+				return nil, nil, fmt.Errorf("%w: --%s", ErrUnknownFlag, name)
+			}
+			if f.isBool && !hasValue {
+				store(f, true)
+				continue
+			}
+			if !hasValue {
+				if i+1 >= len(args) {
+					return nil, nil, fmt.Errorf("%w: --%s", ErrMissingFlagValue, name)
+				}
+				i++
+				value = args[i]
+			}
+			store(f, value)
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			consumed, err := parseShortFlags(arg[1:], args[i+1:], byShort, store)
+			if err != nil {
+				return nil, nil, err
+			}
+			i += consumed
+		default:
+			positional = append(positional, arg)
+		}
+	}
+
+	return inputs, positional, nil
+}
+
+// parseShortFlags parses one "-..." argument (without the dash): a whole short
+// name (-o, -o=json), or a bundle of single-letter flags where each boolean is
+// set true and the first non-boolean takes the rest of the bundle, or else the
+// next argument, as its value (-vojson, -vo json). It returns how many of the
+// following args it consumed.
+func parseShortFlags(shorts string, next []string, byShort map[string]cliFlag, store func(cliFlag, any)) (int, error) {
+	name, value, hasValue := strings.Cut(shorts, "=")
+	if f, ok := byShort[name]; ok {
+		switch {
+		case hasValue:
+			store(f, value)
+		case f.isBool:
+			store(f, true)
+		case len(next) == 0:
+			return 0, fmt.Errorf("%w: -%s", ErrMissingFlagValue, name)
+		default:
+			store(f, next[0])
+			return 1, nil
+		}
+		return 0, nil
+	}
+
+	runes := []rune(shorts)
+	for j, r := range runes {
+		f, ok := byShort[string(r)]
+		if !ok {
+			if r == 'h' {
+				return 0, ErrHelp
+			}
+			return 0, fmt.Errorf("%w: -%c", ErrUnknownFlag, r)
+		}
+
+		rest := string(runes[j+1:])
+		if f.isBool {
+			if strings.HasPrefix(rest, "=") {
+				store(f, rest[1:])
+				return 0, nil
+			}
+			store(f, true)
+			continue
+		}
+
+		if rest != "" {
+			store(f, strings.TrimPrefix(rest, "="))
+			return 0, nil
+		}
+		if len(next) == 0 {
+			return 0, fmt.Errorf("%w: -%c", ErrMissingFlagValue, r)
+		}
+		store(f, next[0])
+		return 1, nil
+	}
+
+	return 0, nil
+}
+
+// SetFromArgs populates the bound struct from command-line arguments parsed
+// by ParseArgs and returns the positional arguments.
+func (m *Struct) SetFromArgs(args []string) ([]string, error) {
+	settings := m.settings()
+	inputs, positional, err := parseArgs(m.structure, settings, args)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing args for struct fields: %w", err)
+	}
+
+	settings.TagOrder = []string{argTag, shortTag}
+	err = SetStructFields(m.structure, settings, inputs)
+	if err != nil {
+		return nil, fmt.Errorf("error setting struct fields: %w", err)
+	}
+
+	return positional, nil
+}
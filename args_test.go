@@ -0,0 +1,152 @@
+package structs
+
+import (
+	"net"
+	"testing"
+)
+
+type argsDatabase struct {
+	DSN string `arg:"dsn" help:"Database connection string"`
+}
+
+type argsConfig struct {
+	Output   string       `arg:"output" short:"o" default:"table" help:"Output format"`
+	Verbose  bool         `arg:"verbose" short:"v"`
+	Quiet    bool         `short:"q"`
+	Color    *bool        `arg:"color"`
+	Limit    int          `arg:"limit" short:"n" default:"50"`
+	Include  []string     `arg:"include" short:"i"`
+	Database argsDatabase `arg:"database"`
+	Ignored  string
+}
+
+func Test_ParseArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		inputs     map[string]any
+		positional []string
+	}{
+		{
+			name:       "long flags with = and separate values",
+			args:       []string{"--output=json", "--limit", "10", "file.txt"},
+			inputs:     map[string]any{"output": "json", "limit": "10"},
+			positional: []string{"file.txt"},
+		},
+		{
+			name:       "short flags with separate, attached and = values",
+			args:       []string{"-o", "yaml", "-n5", "-i=a"},
+			inputs:     map[string]any{"output": "yaml", "limit": "5", "include": MultiValue{"a"}},
+			positional: []string{},
+		},
+		{
+			name:       "bundled short booleans, last one taking a value",
+			args:       []string{"-vqo", "json"},
+			inputs:     map[string]any{"verbose": true, "q": true, "output": "json"},
+			positional: []string{},
+		},
+		{
+			name:       "bundle value attached to the last short",
+			args:       []string{"-vojson"},
+			inputs:     map[string]any{"verbose": true, "output": "json"},
+			positional: []string{},
+		},
+		{
+			name:       "negated booleans",
+			args:       []string{"--no-verbose", "--no-color"},
+			inputs:     map[string]any{"verbose": false, "color": false},
+			positional: []string{},
+		},
+		{
+			name:       "explicit boolean value",
+			args:       []string{"--verbose=false"},
+			inputs:     map[string]any{"verbose": "false"},
+			positional: []string{},
+		},
+		{
+			name:       "repeated flags collect into a MultiValue",
+			args:       []string{"--include", "a,b", "-i", "c", "--limit", "1", "--limit", "2"},
+			inputs:     map[string]any{"include": MultiValue{"a,b", "c"}, "limit": "2"},
+			positional: []string{},
+		},
+		{
+			name:       "nested field by its FQN arg tag",
+			args:       []string{"--database.dsn", "postgres://"},
+			inputs:     map[string]any{"database.dsn": "postgres://"},
+			positional: []string{},
+		},
+		{
+			name:       "terminator ends flag parsing",
+			args:       []string{"-v", "run", "--", "--output", "-x"},
+			inputs:     map[string]any{"verbose": true},
+			positional: []string{"run", "--output", "-x"},
+		},
+		{
+			name:       "a lone dash is positional",
+			args:       []string{"-"},
+			inputs:     map[string]any{},
+			positional: []string{"-"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputs, positional, err := ParseArgs(&argsConfig{}, tt.args)
+			requireNoError(t, err)
+			requireEqual(t, tt.inputs, inputs)
+			requireEqual(t, tt.positional, positional)
+		})
+	}
+}
+
+func Test_ParseArgs_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{name: "unknown long flag", args: []string{"--nope"}, wantErr: ErrUnknownFlag},
+		{name: "unknown short flag", args: []string{"-x"}, wantErr: ErrUnknownFlag},
+		{name: "negating a non-boolean", args: []string{"--no-output"}, wantErr: ErrUnknownFlag},
+		{name: "long flag missing its value", args: []string{"--output"}, wantErr: ErrMissingFlagValue},
+		{name: "short flag missing its value", args: []string{"-vo"}, wantErr: ErrMissingFlagValue},
+		{name: "long help", args: []string{"--help"}, wantErr: ErrHelp},
+		{name: "short help", args: []string{"-h"}, wantErr: ErrHelp},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ParseArgs(&argsConfig{}, tt.args)
+			requireErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_Struct_SetFromArgs(t *testing.T) {
+	cfg := &argsConfig{}
+	positional, err := New(cfg).SetFromArgs([]string{"-v", "--include", "a,b", "-i", "c", "--database.dsn", "postgres://", "--color", "build"})
+	requireNoError(t, err)
+
+	requireEqual(t, []string{"build"}, positional)
+	requireEqual(t, "table", cfg.Output)
+	requireEqual(t, true, cfg.Verbose)
+	requireEqual(t, 50, cfg.Limit)
+	requireEqual(t, []string{"a", "b", "c"}, cfg.Include)
+	requireEqual(t, "postgres://", cfg.Database.DSN)
+	requireNotNil(t, cfg.Color)
+	requireEqual(t, true, *cfg.Color)
+}
+
+func Test_Struct_SetFromArgs_Unmarshaler(t *testing.T) {
+	type target struct {
+		IP net.IP `arg:"ip"`
+	}
+	cfg := &target{}
+	_, err := New(cfg).SetFromArgs([]string{"--ip", "10.0.0.1"})
+	requireNoError(t, err)
+	requireEqual(t, "10.0.0.1", cfg.IP.String())
+
+	inputs, _, err := ParseArgs(&target{}, []string{"--ip", "10.0.0.1", "--ip", "10.0.0.2"})
+	requireNoError(t, err)
+	requireEqual(t, map[string]any{"ip": "10.0.0.2"}, inputs)
+}
@@ -0,0 +1,58 @@
+package structs
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ConverterFunc converts a loosely-typed input (a string from a tag or env var,
+// a value from a decoded config file, ...) into a value of the type it is
+// registered for. The result must be assignable or convertible to that type.
+type ConverterFunc func(value any) (any, error)
+
+// hasConverter reports whether a converter is registered for typ or, when typ
+// is a pointer, for the type it points to.
+func (s Settings) hasConverter(typ reflect.Type) bool {
+	if _, ok := s.Converters[typ]; ok {
+		return true
+	}
+	if typ.Kind() == reflect.Pointer {
+		_, ok := s.Converters[typ.Elem()]
+		return ok
+	}
+	return false
+}
+
+// convertValue sets fieldValue through the converter registered for its type,
+// if any. handled is false when there is none and the built-in coercion should
+// run instead. Pointer fields resolve through setValue's pointer case, which
+// calls back here with the pointed-to value.
+func convertValue(fieldName string, value any, fieldValue reflect.Value, converters map[reflect.Type]ConverterFunc) (bool, error) {
+	if value == nil || !fieldValue.IsValid() {
+		return false, nil
+	}
+	convert, ok := converters[fieldValue.Type()]
+	if !ok {
+		return false, nil
+	}
+
+	converted, err := convert(value)
+	if err != nil {
+		return true, fmt.Errorf("failed to convert %v for field[%s] type %s: %w", value, fieldName, fieldValue.Type(), err)
+	}
+	if converted == nil {
+		fieldValue.Set(reflect.Zero(fieldValue.Type()))
+		return true, nil
+	}
+
+	convertedValue := reflect.ValueOf(converted)
+	if !convertedValue.Type().AssignableTo(fieldValue.Type()) {
+		if !convertedValue.Type().ConvertibleTo(fieldValue.Type()) {
+			return true, fmt.Errorf("converter for field[%s] returned %T, not assignable to %s", fieldName, converted, fieldValue.Type())
+		}
+		convertedValue = convertedValue.Convert(fieldValue.Type())
+	}
+	fieldValue.Set(convertedValue)
+
+	return true, nil
+}
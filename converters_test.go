@@ -0,0 +1,144 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// money stands in for a third-party struct type we can't add methods to.
+type money struct {
+	Cents int64
+}
+
+// serial stands in for an array-backed id type such as uuid.UUID.
+type serial [4]byte
+
+func parseMoney(value any) (any, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("money must be a string, got %T", value)
+	}
+	units, cents, _ := strings.Cut(s, ".")
+	u, err := strconv.ParseInt(units, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	c, err := strconv.ParseInt(cents, 10, 64)
+	if err != nil && cents != "" {
+		return nil, err
+	}
+	return money{Cents: u*100 + c}, nil
+}
+
+func parseSerial(value any) (any, error) {
+	var out serial
+	s := fmt.Sprint(value)
+	if len(s) != len(out) {
+		return nil, errors.New("serial must be 4 characters")
+	}
+	copy(out[:], s)
+	return out, nil
+}
+
+func Test_SetField_Converters(t *testing.T) {
+	type target struct {
+		Price    money      `json:"price"`
+		Fee      money      `json:"fee" default:"0.50"`
+		Discount *money     `json:"discount"`
+		Prices   []money    `json:"prices"`
+		ID       serial     `json:"id"`
+		Addr     netip.Addr `json:"addr"`
+	}
+	settings := Settings{
+		TagOrder:     DefaultTags,
+		EncodingTags: DefaultEncodingTags,
+		Converters: map[reflect.Type]ConverterFunc{
+			reflect.TypeOf(money{}):  parseMoney,
+			reflect.TypeOf(serial{}): parseSerial,
+			// a converter takes precedence over the type's own TextUnmarshaler
+			reflect.TypeOf(netip.Addr{}): func(value any) (any, error) {
+				return netip.ParseAddr(strings.TrimPrefix(fmt.Sprint(value), "ip:"))
+			},
+		},
+	}
+
+	got := &target{}
+	err := SetStructFields(got, settings, map[string]any{
+		"price":    "12.34",
+		"discount": "1.00",
+		"prices":   "1.00, 2.50",
+		"id":       "abcd",
+		"addr":     "ip:10.0.0.1",
+	})
+	requireNoError(t, err)
+	requireEqual(t, money{Cents: 1234}, got.Price)
+	requireEqual(t, money{Cents: 50}, got.Fee)
+	requireNotNil(t, got.Discount)
+	requireEqual(t, money{Cents: 100}, *got.Discount)
+	requireEqual(t, []money{{Cents: 100}, {Cents: 250}}, got.Prices)
+	requireEqual(t, serial{'a', 'b', 'c', 'd'}, got.ID)
+	requireEqual(t, netip.MustParseAddr("10.0.0.1"), got.Addr)
+
+	t.Run("SetField treats a converter struct as a leaf", func(t *testing.T) {
+		override := settings
+		override.AllowTagOverride = true
+		got := &target{}
+		fields, err := GetStructFields(got, nil, DefaultEncodingTags)
+		requireNoError(t, err)
+		for _, field := range fields {
+			if field.Name != "Price" {
+				continue
+			}
+			err = SetField(field, override, map[string]any{"price": "12.34", "Price.Cents": 1})
+			requireNoError(t, err)
+		}
+		requireEqual(t, money{Cents: 1234}, got.Price)
+	})
+
+	t.Run("slice elements go through the converter", func(t *testing.T) {
+		type code string
+		type codes struct {
+			One    code    `json:"one"`
+			Codes  []code  `json:"codes"`
+			Prices []money `json:"prices"`
+		}
+		withCodes := settings
+		withCodes.Converters = map[reflect.Type]ConverterFunc{
+			reflect.TypeOf(code("")): func(value any) (any, error) {
+				return code(strings.ToUpper(fmt.Sprint(value))), nil
+			},
+			reflect.TypeOf(money{}): func(value any) (any, error) {
+				units, ok := value.(float64)
+				if !ok {
+					return parseMoney(value)
+				}
+				return money{Cents: int64(units * 100)}, nil
+			},
+		}
+		got := &codes{}
+		err := SetStructFields(got, withCodes, map[string]any{"one": "ab", "codes": "ab,cd", "prices": []any{1.5, "2.25"}})
+		requireNoError(t, err)
+		requireEqual(t, code("AB"), got.One)
+		requireEqual(t, []code{"AB", "CD"}, got.Codes)
+		requireEqual(t, []money{{Cents: 150}, {Cents: 225}}, got.Prices)
+	})
+
+	t.Run("converter failure names the field", func(t *testing.T) {
+		err := SetStructFields(&target{}, settings, map[string]any{"id": "toolong"})
+		requireErrorContains(t, err, "field[ID]")
+	})
+
+	t.Run("converter returning the wrong type errors", func(t *testing.T) {
+		bad := settings
+		bad.Converters = map[reflect.Type]ConverterFunc{
+			reflect.TypeOf(money{}): func(any) (any, error) { return "nope", nil },
+		}
+		err := SetStructFields(&target{}, bad, map[string]any{"price": "1"})
+		requireErrorContains(t, err, "not assignable")
+	})
+}
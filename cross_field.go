@@ -0,0 +1,145 @@
+package structs
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/toaweme/structs/utils"
+)
+
+// RequiredIf makes a field required when another field has one of the given
+// values, e.g. `rules:"required_if:TLS,true"`. The other field is named by Go
+// name, tag name or FQN ("TLS", "tls", "Server.TLS", "server.tls"); a bool
+// arg matches the truthy forms Set accepts ("yes", "1").
+var RequiredIf RuleFunc = func(fieldName string, values map[string]any, defaultValue string, fieldValue reflect.Value, args []string) (map[string][]string, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("rule required_if expects a field and at least 1 value, got %d args", len(args))
+	}
+
+	other, ok := referenceText(values, args[0])
+	if !ok || !matchesAny(other, args[1:]) {
+		//nolint:nilnil // nil, nil means no validation and internal errors
+		return nil, nil
+	}
+
+	return requiredBecause(fieldName, values, defaultValue, fieldValue, "required when "+args[0]+" is "+strings.Join(args[1:], " or "))
+}
+
+// RequiredWith makes a field required when any of the named fields is set,
+// e.g. `rules:"required_with:Cert,Key"`.
+var RequiredWith RuleFunc = func(fieldName string, values map[string]any, defaultValue string, fieldValue reflect.Value, args []string) (map[string][]string, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("rule required_with expects at least 1 field")
+	}
+
+	for _, name := range args {
+		if _, ok := referenceText(values, name); ok {
+			return requiredBecause(fieldName, values, defaultValue, fieldValue, "required when "+name+" is set")
+		}
+	}
+
+	//nolint:nilnil // nil, nil means no validation and internal errors
+	return nil, nil
+}
+
+// RequiredWithout makes a field required when any of the named fields is not
+// set, e.g. `rules:"required_without:Token"` on a password.
+var RequiredWithout RuleFunc = func(fieldName string, values map[string]any, defaultValue string, fieldValue reflect.Value, args []string) (map[string][]string, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("rule required_without expects at least 1 field")
+	}
+
+	for _, name := range args {
+		if _, ok := referenceText(values, name); !ok {
+			return requiredBecause(fieldName, values, defaultValue, fieldValue, "required when "+name+" is not set")
+		}
+	}
+
+	//nolint:nilnil // nil, nil means no validation and internal errors
+	return nil, nil
+}
+
+// EqField fails when a field differs from the named field, e.g.
+// `rules:"eqfield:Password"` on a confirmation field. Both are compared in
+// text form; two unset fields are equal.
+var EqField RuleFunc = func(fieldName string, values map[string]any, defaultValue string, fieldValue reflect.Value, args []string) (map[string][]string, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("rule eqfield expects 1 field, got %d args", len(args))
+	}
+
+	value, _ := formatValue(fieldName, values, defaultValue, fieldValue)
+	other, _ := referenceText(values, args[0])
+	if value == other {
+		//nolint:nilnil // nil, nil means no validation and internal errors
+		return nil, nil
+	}
+
+	return map[string][]string{
+		fieldName: {"must equal " + args[0]},
+	}, nil
+}
+
+// fieldRuleArgs is how many leading args of a cross-field rule name fields,
+// -1 for all of them. The validator resolves those names to the fields'
+// values and rejects names that match no field.
+var fieldRuleArgs = map[string]int{
+	"required_if":      1,
+	"required_with":    -1,
+	"required_without": -1,
+	"eqfield":          1,
+}
+
+// requiredBecause runs Required, reporting its failure with message.
+func requiredBecause(fieldName string, values map[string]any, defaultValue string, fieldValue reflect.Value, message string) (map[string][]string, error) {
+	errors, err := Required(fieldName, values, defaultValue, fieldValue, nil)
+	if err != nil || len(errors) == 0 {
+		return errors, err
+	}
+
+	return map[string][]string{
+		fieldName: {message},
+	}, nil
+}
+
+// referenceText is the text form of a referenced field's value, false when it
+// is unset or blank.
+func referenceText(values map[string]any, name string) (string, bool) {
+	raw, ok := values[name]
+	if !ok || raw == nil {
+		return "", false
+	}
+
+	text := fmt.Sprintf("%v", raw)
+	if s, isStr := raw.(string); isStr {
+		text = strings.TrimSpace(s)
+	}
+
+	return text, text != ""
+}
+
+// matchesAny reports whether text equals one of wanted; a "true" or "false"
+// in wanted matches text by truthiness.
+func matchesAny(text string, wanted []string) bool {
+	for _, want := range wanted {
+		switch {
+		case want == text:
+			return true
+		case want == "true" && utils.ParseBool(text):
+			return true
+		case want == "false" && isFalsy(text):
+			return true
+		}
+	}
+	return false
+}
+
+// isFalsy reports whether text is one of the falsy forms Set accepts.
+func isFalsy(text string) bool {
+	switch strings.ToLower(text) {
+	case "false", "no", "0":
+		return true
+	default:
+		return false
+	}
+}
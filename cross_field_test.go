@@ -0,0 +1,99 @@
+package structs
+
+import (
+	"testing"
+)
+
+type crossFieldTLS struct {
+	Enabled bool   `json:"enabled" default:"false"`
+	Cert    string `json:"cert" rules:"required_if:Enabled,true"`
+	Key     string `json:"key" rules:"required_with:cert"`
+}
+
+type crossFieldConfig struct {
+	Mode            string        `json:"mode" default:"dev"`
+	DSN             string        `json:"dsn" rules:"required_if:mode,prod,staging"`
+	Token           string        `json:"token" env:"TOKEN"`
+	Password        string        `json:"password" rules:"required_without:TOKEN"`
+	PasswordConfirm string        `json:"password_confirm" rules:"eqfield:Password"`
+	TLS             crossFieldTLS `json:"tls"`
+	Redirect        bool          `json:"redirect" rules:"required_if:tls.enabled,false"`
+}
+
+func Test_Validate_CrossField(t *testing.T) {
+	tests := []struct {
+		name           string
+		values         map[string]any
+		expectedErrors map[string][]string
+	}{
+		{
+			name:           "conditions not met pass",
+			values:         map[string]any{"token": "t", "redirect": true},
+			expectedErrors: map[string][]string{},
+		},
+		{
+			name:   "required_if matches any listed value, by tag",
+			values: map[string]any{"mode": "staging", "token": "t", "redirect": true},
+			expectedErrors: map[string][]string{
+				"dsn": {"required when mode is prod or staging"},
+			},
+		},
+		{
+			name:   "required_without fires when the other field is unset, by env tag",
+			values: map[string]any{"redirect": true},
+			expectedErrors: map[string][]string{
+				"password": {"required when TOKEN is not set"},
+			},
+		},
+		{
+			name:           "required_without is satisfied through the env key",
+			values:         map[string]any{"TOKEN": "t", "redirect": true},
+			expectedErrors: map[string][]string{},
+		},
+		{
+			name:   "eqfield compares against the other field, by Go name",
+			values: map[string]any{"password": "secret", "password_confirm": "secrte", "redirect": true},
+			expectedErrors: map[string][]string{
+				"password_confirm": {"must equal Password"},
+			},
+		},
+		{
+			name:   "nested rules reference siblings by Go name and tag",
+			values: map[string]any{"token": "t", "tls": map[string]any{"enabled": "yes", "cert": " "}},
+			expectedErrors: map[string][]string{
+				"tls.cert": {"required when Enabled is true"},
+			},
+		},
+		{
+			name:   "a sibling set by dotted key triggers required_with",
+			values: map[string]any{"token": "t", "redirect": true, "tls.cert": "c.pem"},
+			expectedErrors: map[string][]string{
+				"tls.key": {"required when cert is set"},
+			},
+		},
+		{
+			name:   "a nested field is referenced by its dotted tag, defaults included",
+			values: map[string]any{"token": "t"},
+			expectedErrors: map[string][]string{
+				"redirect": {"required when tls.enabled is false"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs, err := New(&crossFieldConfig{}, WithTags("json")).Validate(tt.values)
+			requireNoError(t, err)
+			requireEqual(t, tt.expectedErrors, errs)
+		})
+	}
+}
+
+func Test_Validate_CrossFieldUnknownReference(t *testing.T) {
+	type target struct {
+		Cert string `json:"cert" rules:"required_if:TSL,true"`
+	}
+
+	_, err := New(&target{}, WithTags("json")).Validate(map[string]any{})
+	requireErrorContains(t, err, `no field named "TSL"`)
+}
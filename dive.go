@@ -0,0 +1,185 @@
+package structs
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+const diveRule = "dive"
+const keysRule = "keys"
+const endKeysRule = "endkeys"
+
+// splitDive splits a field's rules at "dive": the rules before it apply to
+// the field itself, the rules after it to each slice, array or map element.
+// For a map, rules between "keys" and "endkeys" right after "dive" apply to
+// each key, e.g. `rules:"max:8|dive|keys|hostname|endkeys|min:1"`. A nested
+// struct element is also validated by its own `rules:` tags.
+func splitDive(rules []Rule) (fieldRules, keyRules, elementRules []Rule, dive bool) {
+	for i, rule := range rules {
+		if rule.Name != diveRule {
+			continue
+		}
+
+		fieldRules, elementRules = rules[:i], rules[i+1:]
+		if len(elementRules) > 0 && elementRules[0].Name == keysRule {
+			for j, keyRule := range elementRules {
+				if keyRule.Name == endKeysRule {
+					keyRules, elementRules = elementRules[1:j], elementRules[j+1:]
+					break
+				}
+			}
+		}
+		return fieldRules, keyRules, elementRules, true
+	}
+
+	return rules, nil, nil, false
+}
+
+// diveInput is the collection a dive rule walks: the field's input, else its
+// default.
+func diveInput(values map[string]any, fieldName, defaultValue string) any {
+	if value, ok := values[fieldName]; ok && value != nil {
+		return value
+	}
+	if defaultValue != "" {
+		return defaultValue
+	}
+	return nil
+}
+
+// validateElements applies keyRules and elementRules to each element of
+// collection, reported under "field[i]" for slices and arrays and "field.key"
+// for maps, and validates struct elements by their own rules under
+// "field[i].sub" or "field.key.sub". Map keys are visited in sorted order.
+func (v validator) validateElements(structField Field, fieldName string, collection any, keyRules, elementRules []Rule, validationErrors *ValidationErrors) error {
+	fieldType := structField.Value.Type()
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	switch fieldType.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+	default:
+		return fmt.Errorf("rule dive needs a slice, array or map field, not %s", fieldType.Kind())
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Slice, reflect.Array:
+		// a flat "a,b,c" input is split the way Set splits it
+		collection = splitSliceInput(structField, Settings{}, collection)
+	case reflect.Map:
+		// as is a flat "k1=v1,k2=v2" one; a malformed one is left for Set to report
+		if pairs, err := splitMapInput(structField, collection); err == nil {
+			collection = pairs
+		}
+	}
+
+	elements := reflect.Indirect(reflect.ValueOf(collection))
+	switch {
+	case fieldType.Kind() != reflect.Map && (elements.Kind() == reflect.Slice || elements.Kind() == reflect.Array):
+		for i := range elements.Len() {
+			path := fmt.Sprintf("%s[%d]", fieldName, i)
+			err := v.validateElement(structField, path, elements.Index(i), fieldType.Elem(), elementRules, validationErrors)
+			if err != nil {
+				return err
+			}
+		}
+	case fieldType.Kind() == reflect.Map && elements.Kind() == reflect.Map:
+		keys := elements.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprintf("%v", keys[i].Interface()) < fmt.Sprintf("%v", keys[j].Interface())
+		})
+		for _, key := range keys {
+			path := fmt.Sprintf("%s.%v", fieldName, key.Interface())
+			err := v.validateElement(structField, path, key, fieldType.Key(), keyRules, validationErrors)
+			if err != nil {
+				return err
+			}
+			err = v.validateElement(structField, path, elements.MapIndex(key), fieldType.Elem(), elementRules, validationErrors)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// any other input (e.g. a scalar for a slice) is left for Set to report
+	return nil
+}
+
+// validateElement runs rules against one element, then validates a struct
+// element's own fields.
+func (v validator) validateElement(structField Field, path string, element reflect.Value, elementType reflect.Type, rules []Rule, validationErrors *ValidationErrors) error {
+	for element.Kind() == reflect.Interface && !element.IsNil() {
+		element = element.Elem()
+	}
+
+	var value any
+	if element.IsValid() && element.CanInterface() && !(element.Kind() == reflect.Interface && element.IsNil()) {
+		value = element.Interface()
+	}
+
+	// the element's declared type decides what rules measure, the value's own
+	// type when it is declared as an interface
+	var zero reflect.Value
+	if elementType.Kind() != reflect.Interface {
+		zero = reflect.New(elementType).Elem()
+	}
+	// a nil element reads as unset
+	values := map[string]any{}
+	if value != nil {
+		values[path] = value
+	}
+	for _, rule := range rules {
+		err := v.applyRule(structField, rule, path, "", values, "", zero, validationErrors)
+		if err != nil {
+			return err
+		}
+	}
+
+	if value == nil || !isNestedStruct(elementType) {
+		return nil
+	}
+
+	return v.validateStructElement(path, value, elementType, validationErrors)
+}
+
+// validateStructElement validates a struct element by its fields' rules,
+// from a decoded map input (YAML's map[any]any included) or from the struct
+// value itself, and reports the failures under path.
+func (v validator) validateStructElement(path string, value any, elementType reflect.Type, validationErrors *ValidationErrors) error {
+	structType := elementType
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+
+	element := reflect.New(structType)
+	sub := v
+	input, isInput := mapInput(value)
+	if isInput {
+		sub.current = false
+	} else {
+		current := reflect.Indirect(reflect.ValueOf(value))
+		if !current.IsValid() || !current.Type().AssignableTo(structType) {
+			// neither an input section nor the struct: Set will report it
+			return nil
+		}
+		element.Elem().Set(current)
+		sub.current = true
+	}
+
+	elementFields, err := GetStructFields(element.Interface(), nil, v.encodingTags)
+	if err != nil {
+		return err
+	}
+
+	elementErrors, err := sub.validate(elementFields, input)
+	if err != nil {
+		return err
+	}
+	for _, fieldError := range elementErrors {
+		fieldError.Field = path + "." + fieldError.Field
+		*validationErrors = append(*validationErrors, fieldError)
+	}
+
+	return nil
+}
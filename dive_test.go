@@ -0,0 +1,118 @@
+package structs
+
+import (
+	"errors"
+	"testing"
+)
+
+type diveEndpoint struct {
+	URL    string `json:"url" rules:"required|url:http,https"`
+	Weight int    `json:"weight" rules:"between:1,100"`
+}
+
+type diveBackend struct {
+	Weight int `json:"weight" rules:"max:10"`
+}
+
+type diveConfig struct {
+	Endpoints []diveEndpoint          `json:"endpoints" rules:"min:1|dive"`
+	Backends  map[string]*diveBackend `json:"backends" rules:"dive|keys|len:2|endkeys|required"`
+	Tags      []string                `json:"tags" rules:"dive|hostname"`
+	Ports     [2]int                  `json:"ports" rules:"dive|port"`
+	Labels    map[string]string       `json:"labels" rules:"dive|keys|regex:^[a-z]+$|endkeys"`
+}
+
+func Test_Validate_Dive(t *testing.T) {
+	tests := []struct {
+		name           string
+		values         map[string]any
+		expectedErrors map[string][]string
+	}{
+		{
+			name: "valid elements pass",
+			values: map[string]any{
+				"endpoints": []any{map[string]any{"url": "https://a.example.com", "weight": 50}},
+				"backends":  map[string]any{"eu": map[string]any{"weight": 3}},
+				"tags":      "edge,api.example.com",
+				"labels":    map[string]any{"env": "prod"},
+			},
+			expectedErrors: map[string][]string{},
+		},
+		{
+			name: "struct elements are validated by their own rules under indexed paths",
+			values: map[string]any{
+				"endpoints": []any{
+					map[string]any{"url": "https://a.example.com", "weight": 50},
+					map[string]any{"url": "https://b.example.com"},
+					map[string]any{"url": "ftp://c.example.com", "weight": 500},
+				},
+				"backends": map[string]any{"us": map[string]any{"weight": 3}, "eu": map[string]any{"weight": 30}},
+			},
+			expectedErrors: map[string][]string{
+				"endpoints[2].url":    {"must be a valid URL with scheme: http, https"},
+				"endpoints[2].weight": {"must be between 1 and 100"},
+				"backends.eu.weight":  {"must be at most 10"},
+			},
+		},
+		{
+			name: "decoded YAML map[any]any elements are validated too",
+			values: map[string]any{
+				"endpoints": []any{map[any]any{"url": "ftp://c.example.com", "weight": 500}},
+				"backends":  map[string]any{"eu": map[any]any{"weight": 30}},
+			},
+			expectedErrors: map[string][]string{
+				"endpoints[0].url":    {"must be a valid URL with scheme: http, https"},
+				"endpoints[0].weight": {"must be between 1 and 100"},
+				"backends.eu.weight":  {"must be at most 10"},
+			},
+		},
+		{
+			name: "element and key rules",
+			values: map[string]any{
+				"endpoints": []any{},
+				"backends":  map[string]any{"eu": nil, "west": map[string]any{}},
+				"tags":      []any{"ok", "not ok"},
+				"labels":    map[string]any{"Env": "prod", "tier": "db"},
+			},
+			expectedErrors: map[string][]string{
+				"endpoints":     {"must have at least 1 item"},
+				"backends.eu":   {"required"},
+				"backends.west": {"must be exactly 2 characters long"},
+				"tags[1]":       {"must be a valid hostname"},
+				"labels.Env":    {"must match ^[a-z]+$"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs, err := New(&diveConfig{}, WithTags("json")).Validate(tt.values)
+			requireNoError(t, err)
+			requireEqual(t, tt.expectedErrors, errs)
+		})
+	}
+}
+
+func Test_ValidateValue_Dive(t *testing.T) {
+	cfg := &diveConfig{
+		Endpoints: []diveEndpoint{{URL: "https://a.example.com", Weight: 1}, {Weight: 2}},
+		Backends:  map[string]*diveBackend{"eu": {Weight: 11}},
+		Ports:     [2]int{80, 70000},
+	}
+
+	err := New(cfg, WithTags("json")).CheckValue()
+	var validationErrors ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	requireEqual(t, "endpoints[1].url: required; backends.eu.weight: must be at most 10; ports[1]: must be a valid port", validationErrors.Error())
+}
+
+func Test_Validate_DiveNeedsCollection(t *testing.T) {
+	type target struct {
+		Name string `json:"name" rules:"dive|required"`
+	}
+
+	_, err := New(&target{}, WithTags("json")).Validate(map[string]any{"name": "x"})
+	requireErrorContains(t, err, "rule dive needs a slice, array or map field")
+}
@@ -0,0 +1,65 @@
+package structs
+
+import (
+	"fmt"
+	"os"
+)
+
+// FromEnv reads the environment variables structure's fields ask for and
+// returns them as an inputs map for Set/SetStructFields. Only fields with an
+// `env:` tag are read, nested fields by their "_"-glued FQN env key
+// (DATABASE_DSN), so unrelated variables never leak in. prefix, if not
+// empty, is prepended to every key when reading (MYAPP_ + PORT reads
+// MYAPP_PORT), while the returned map stays keyed by the unprefixed env tag
+// value, which is what SetField matches. Unset variables are left out; a
+// variable set to "" is kept.
+func FromEnv(structure any, prefix string) (map[string]any, error) {
+	return envInputs(structure, prefix, os.LookupEnv)
+}
+
+func envInputs(structure any, prefix string, lookup func(string) (string, bool)) (map[string]any, error) {
+	fields, err := GetStructFields(structure, nil, DefaultEncodingTags)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make(map[string]any)
+	collectEnvInputs(fields, prefix, lookup, inputs)
+
+	return inputs, nil
+}
+
+func collectEnvInputs(fields []Field, prefix string, lookup func(string) (string, bool), inputs map[string]any) {
+	for _, field := range fields {
+		if envKey := fieldEnvKey(field); envKey != "" {
+			if value, ok := lookup(prefix + envKey); ok {
+				inputs[envKey] = value
+			}
+		}
+
+		if field.Fields != nil {
+			collectEnvInputs(field.Fields, prefix, lookup, inputs)
+		}
+	}
+}
+
+// fieldEnvKey returns the env key SetField matches field by: its FQN env tag
+// when nested (glued to its parents' with "_"), else its own. "" when the
+// field has no env tag.
+func fieldEnvKey(field Field) string {
+	if field.FQN != nil {
+		return field.FQN.Tags[envValueTag]
+	}
+	return field.Tags[envValueTag]
+}
+
+// SetFromEnv populates the bound struct from the environment variables its
+// fields' env tags name, each read with prefix prepended. See FromEnv.
+func (m *Struct) SetFromEnv(prefix string) error {
+	inputs, err := FromEnv(m.structure, prefix)
+	if err != nil {
+		return fmt.Errorf("error reading env for struct fields: %w", err)
+	}
+
+	return m.Set(inputs)
+}
@@ -0,0 +1,64 @@
+package structs
+
+import (
+	"testing"
+)
+
+type envDatabase struct {
+	DSN  string `json:"dsn" env:"DSN"`
+	Pool int    `json:"pool"`
+}
+
+type envConfig struct {
+	Host     string       `json:"host" env:"HOST"`
+	Port     int          `json:"port" env:"PORT" default:"8080"`
+	Debug    bool         `json:"debug"`
+	Database envDatabase  `json:"database" env:"DATABASE"`
+	Replica  *envDatabase `json:"replica" env:"REPLICA"`
+}
+
+func Test_FromEnv(t *testing.T) {
+	t.Setenv("HOST", "0.0.0.0")
+	t.Setenv("DATABASE_DSN", "postgres://primary")
+	t.Setenv("REPLICA_DSN", "postgres://replica")
+	t.Setenv("DEBUG", "true")
+	t.Setenv("UNRELATED", "x")
+	t.Setenv("MYAPP_HOST", "127.0.0.1")
+	t.Setenv("MYAPP_PORT", "")
+
+	t.Run("reads only env-tagged fields, nested ones by glued FQN key", func(t *testing.T) {
+		got, err := FromEnv(&envConfig{}, "")
+		requireNoError(t, err)
+		requireEqual(t, map[string]any{
+			"HOST":         "0.0.0.0",
+			"DATABASE_DSN": "postgres://primary",
+			"REPLICA_DSN":  "postgres://replica",
+		}, got)
+	})
+
+	t.Run("prefix is read but not returned", func(t *testing.T) {
+		got, err := FromEnv(&envConfig{}, "MYAPP_")
+		requireNoError(t, err)
+		requireEqual(t, map[string]any{
+			"HOST": "127.0.0.1",
+			"PORT": "",
+		}, got)
+	})
+
+	t.Run("SetFromEnv populates the struct", func(t *testing.T) {
+		cfg := &envConfig{}
+		err := New(cfg).SetFromEnv("")
+		requireNoError(t, err)
+		requireEqual(t, "0.0.0.0", cfg.Host)
+		requireEqual(t, 8080, cfg.Port)
+		requireEqual(t, false, cfg.Debug)
+		requireEqual(t, "postgres://primary", cfg.Database.DSN)
+		requireNotNil(t, cfg.Replica)
+		requireEqual(t, "postgres://replica", cfg.Replica.DSN)
+	})
+
+	t.Run("non-pointer structure errors", func(t *testing.T) {
+		_, err := FromEnv(envConfig{}, "")
+		requireErrorIs(t, err, ErrInputPointer)
+	})
+}
@@ -1,9 +1,9 @@
 package structs_test
 
 import (
+	"errors"
 	"fmt"
 	"sort"
-	"strings"
 
 	"github.com/toaweme/structs"
 )
@@ -168,11 +168,39 @@ func Example_validation() {
 	// name: [required]
 }
 
-// Example_cliArgs shows that structs is agnostic about where the input map
-// comes from. It does not parse argv itself; you bring your own flag parser
-// (here the toy cliArgsToMap), and structs matches the resulting keys against
-// the arg/short tags. A bool flag with no value becomes true, and a string
-// argument is coerced into the field's type.
+// Example_orderedValidation prints validation failures in a stable order, the
+// way a CLI should. Check returns ValidationErrors in struct declaration order
+// (rule order within a field), and Ordered groups them by field without
+// losing that order, so there is no map to sort.
+func Example_orderedValidation() {
+	type Args struct {
+		Name   string `json:"name" rules:"required"`
+		Format string `json:"format" rules:"oneof:json,yaml,toml"`
+		Port   int    `json:"port" rules:"between:1,65535"`
+	}
+
+	manager := structs.New(&Args{}, structs.WithTags("json"))
+
+	err := manager.Check(map[string]any{"format": "xml", "port": 70000})
+
+	var validationErrors structs.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		panic(err)
+	}
+	for _, field := range validationErrors.Ordered() {
+		fmt.Printf("%s: %v\n", field.Field, field.Messages)
+	}
+	// Output:
+	// name: [required]
+	// format: [must be one of: json, yaml, toml]
+	// port: [must be between 1 and 65535]
+}
+
+// Example_cliArgs shows the same struct tags driving a command line.
+// ParseArgs reads argv against the arg/short tags and returns an input map
+// keyed by flag name plus the positional arguments; structs then validates and
+// sets it like any other source. A bool flag with no value becomes true, and a
+// string argument is coerced into the field's type.
 func Example_cliArgs() {
 	type Flags struct {
 		Output  string `arg:"output" short:"o" default:"table" rules:"oneof:table,json,yaml"`
@@ -180,9 +208,12 @@ func Example_cliArgs() {
 		Limit   int    `arg:"limit" default:"50"`
 	}
 
-	values := cliArgsToMap([]string{"--output", "json", "-v", "--limit", "10"})
-
 	flags := &Flags{}
+	values, positional, err := structs.ParseArgs(flags, []string{"--output", "json", "-v", "--limit", "10", "report.csv"})
+	if err != nil {
+		panic(err)
+	}
+
 	manager := structs.New(flags, structs.WithTags("arg", "short"))
 
 	if errs, err := manager.Validate(values); err != nil {
@@ -195,29 +226,7 @@ func Example_cliArgs() {
 		panic(err)
 	}
 
-	fmt.Printf("output=%s verbose=%t limit=%d\n", flags.Output, flags.Verbose, flags.Limit)
+	fmt.Printf("output=%s verbose=%t limit=%d args=%v\n", flags.Output, flags.Verbose, flags.Limit, positional)
 	// Output:
-	// output=json verbose=true limit=10
-}
-
-// cliArgsToMap is a stand-in for whatever flag parser you already use: it turns
-// raw argv into a map keyed by flag name (the leading dashes stripped). A flag
-// followed by a non-flag token takes that token as its value; otherwise it is
-// treated as a present boolean. structs never sees argv, only this map.
-func cliArgsToMap(args []string) map[string]any {
-	out := make(map[string]any)
-	for i := 0; i < len(args); i++ {
-		arg := args[i]
-		if !strings.HasPrefix(arg, "-") {
-			continue
-		}
-		name := strings.TrimLeft(arg, "-")
-		if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
-			out[name] = args[i+1]
-			i++
-			continue
-		}
-		out[name] = true
-	}
-	return out
+	// output=json verbose=true limit=10 args=[report.csv]
 }
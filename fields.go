@@ -7,6 +7,10 @@ import (
 
 // Rule is a single validation rule parsed from a `rules:` tag entry. For
 // `rules:"oneof:json,yaml"` Name is "oneof" and Args is ["json", "yaml"].
+//
+// An arg wrapped in single quotes is taken literally, so it may contain the
+// "|", "," and ":" the syntax otherwise splits on: `rules:"regex:'^[a-z]{2,8}$'"`
+// has the single arg `^[a-z]{2,8}$`. Inside quotes, double a quote to keep it.
 type Rule struct {
 	// Name is the rule identifier, used to look up its RuleFunc in the rule set.
 	Name string
@@ -17,20 +21,58 @@ type Rule struct {
 func parseRules(rules []string) []Rule {
 	parsedRules := make([]Rule, 0)
 	for _, rule := range rules {
-		parts := strings.Split(rule, ":")
-		r := Rule{Name: parts[0]}
-		if len(parts) > 1 {
-			r.Args = strings.Split(parts[1], ",")
+		name, args, hasArgs := strings.Cut(rule, ":")
+		r := Rule{Name: name}
+		if hasArgs {
+			for _, arg := range splitQuoted(args, ',') {
+				r.Args = append(r.Args, unquoteArg(arg))
+			}
 		}
 		parsedRules = append(parsedRules, r)
 	}
 	return parsedRules
 }
 
+// splitQuoted splits s on sep, except inside a single-quoted arg. A quote
+// opens an arg only at its start (after sep, ":" or ",") and closes it only at
+// its end (before sep, "," or the end of s), so a stray apostrophe in an
+// unquoted arg is kept as-is.
+func splitQuoted(s string, sep byte) []string {
+	parts := make([]string, 0)
+	start := 0
+	inQuote := false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\'' && !inQuote:
+			inQuote = i == start || s[i-1] == ':' || s[i-1] == ','
+		case s[i] == '\'' && inQuote:
+			inQuote = i+1 < len(s) && s[i+1] != sep && s[i+1] != ','
+		case s[i] == sep && !inQuote:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unquoteArg strips the single quotes around a quoted arg and undoubles the
+// quotes inside it.
+func unquoteArg(arg string) string {
+	if len(arg) < 2 || arg[0] != '\'' || arg[len(arg)-1] != '\'' {
+		return arg
+	}
+	return strings.ReplaceAll(arg[1:len(arg)-1], "''", "'")
+}
+
 const defaultValueTag = "default"
 const envValueTag = "env"
 const rulesTag = "rules"
 const separatorTag = "sep"
+const layoutTag = "layout"
+const argTag = "arg"
+const shortTag = "short"
+const helpTag = "help"
+const msgTag = "msg"
 const defaultSeparator = ","
 
 // Field is the reflected description of one struct field, produced by
@@ -58,8 +100,13 @@ type Field struct {
 	FQN *Field
 	// Parent points to the enclosing struct's field, nil for top-level fields.
 	Parent *Field
-	// Fields are the nested fields when Kind is reflect.Struct.
+	// Fields are the nested fields when Kind is reflect.Struct, or
+	// reflect.Pointer to a struct.
 	Fields []Field
+
+	// pending is the struct allocated for a nil pointer-to-struct field, which
+	// its nested Fields write into until SetFields attaches it.
+	pending reflect.Value
 }
 
 // NewField builds a Field from a struct field's name, kind, value, and parsed
@@ -71,7 +118,7 @@ func NewField(name string, dataType reflect.Kind, value reflect.Value, tags map[
 		delete(tags, defaultValueTag)
 	}
 	if rules, ok := tags[rulesTag]; ok {
-		f.Rules = parseRules(strings.Split(rules, "|"))
+		f.Rules = parseRules(splitQuoted(rules, '|'))
 		delete(tags, rulesTag)
 	}
 	f.Value = value
@@ -0,0 +1,200 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Email fails unless the field is a bare email address ("ops@example.com"),
+// without a display name or angle brackets.
+var Email = formatRule("must be a valid email address", func(value string, _ []string) (bool, error) {
+	address, err := mail.ParseAddress(value)
+	return err == nil && address.Address == value, nil
+})
+
+// URL fails unless the field is an absolute URL with a host. Args, when given,
+// restrict the scheme, e.g. `rules:"url:http,https"`.
+var URL RuleFunc = func(fieldName string, values map[string]any, defaultValue string, fieldValue reflect.Value, args []string) (map[string][]string, error) {
+	message := "must be a valid URL"
+	if len(args) > 0 {
+		message = "must be a valid URL with scheme: " + strings.Join(args, ", ")
+	}
+
+	return formatRule(message, func(value string, args []string) (bool, error) {
+		u, err := url.Parse(value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return false, nil
+		}
+		if len(args) == 0 {
+			return true, nil
+		}
+		for _, scheme := range args {
+			if strings.EqualFold(u.Scheme, scheme) {
+				return true, nil
+			}
+		}
+		return false, nil
+	})(fieldName, values, defaultValue, fieldValue, args)
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// UUID fails unless the field is a hyphenated UUID of any version.
+var UUID = formatRule("must be a valid UUID", func(value string, _ []string) (bool, error) {
+	return uuidPattern.MatchString(value), nil
+})
+
+// IP fails unless the field is an IPv4 or IPv6 address.
+var IP = formatRule("must be a valid IP address", func(value string, _ []string) (bool, error) {
+	_, err := netip.ParseAddr(value)
+	return err == nil, nil
+})
+
+// IPv4 fails unless the field is an IPv4 address.
+var IPv4 = formatRule("must be a valid IPv4 address", func(value string, _ []string) (bool, error) {
+	addr, err := netip.ParseAddr(value)
+	return err == nil && addr.Is4(), nil
+})
+
+// IPv6 fails unless the field is an IPv6 address (IPv4-mapped ones included).
+var IPv6 = formatRule("must be a valid IPv6 address", func(value string, _ []string) (bool, error) {
+	addr, err := netip.ParseAddr(value)
+	return err == nil && addr.Is6(), nil
+})
+
+// CIDR fails unless the field is an IP prefix such as "10.0.0.0/8".
+var CIDR = formatRule("must be a valid CIDR", func(value string, _ []string) (bool, error) {
+	_, err := netip.ParsePrefix(value)
+	return err == nil, nil
+})
+
+// Hostname fails unless the field is an RFC 1123 hostname: dot-separated
+// labels of letters, digits and inner hyphens, each at most 63 characters and
+// 253 in total. A single trailing dot is allowed.
+var Hostname = formatRule("must be a valid hostname", func(value string, _ []string) (bool, error) {
+	return isHostname(value), nil
+})
+
+// Port fails unless the field is a TCP/UDP port number, 1 to 65535.
+var Port = formatRule("must be a valid port", func(value string, _ []string) (bool, error) {
+	port, err := strconv.ParseUint(value, 10, 16)
+	return err == nil && port > 0, nil
+})
+
+// Regex fails unless the field matches the pattern arg, unanchored as in
+// regexp.MatchString. Quote the pattern when it contains "|" or ",":
+// `rules:"regex:'^[a-z]{2,8}$'"`. An invalid pattern is an error, not a
+// validation failure.
+var Regex RuleFunc = func(fieldName string, values map[string]any, defaultValue string, fieldValue reflect.Value, args []string) (map[string][]string, error) {
+	if len(args) == 0 {
+		return nil, errors.New("rule regex expects 1 arg, got 0")
+	}
+	if len(args) > 1 {
+		// an unquoted pattern such as ^a{2,8}$ was split on its comma
+		return nil, fmt.Errorf("rule regex expects 1 arg, got %d: single-quote a pattern containing \",\", e.g. regex:'%s'",
+			len(args), strings.Join(args, ","))
+	}
+
+	return formatRule("must match "+args[0], func(value string, args []string) (bool, error) {
+		pattern, err := compilePattern(args[0])
+		if err != nil {
+			return false, err
+		}
+		return pattern.MatchString(value), nil
+	})(fieldName, values, defaultValue, fieldValue, args)
+}
+
+// patterns caches the compiled regex rule patterns, by source.
+var patterns sync.Map
+
+func compilePattern(source string) (*regexp.Regexp, error) {
+	if pattern, ok := patterns.Load(source); ok {
+		return pattern.(*regexp.Regexp), nil
+	}
+
+	pattern, err := regexp.Compile(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", source, err)
+	}
+	patterns.Store(source, pattern)
+
+	return pattern, nil
+}
+
+// formatRule builds a RuleFunc that checks the field's text form with valid.
+// Like OneOf, an empty value passes (pair with `required` to force presence).
+func formatRule(message string, valid func(value string, args []string) (bool, error)) RuleFunc {
+	return func(fieldName string, values map[string]any, defaultValue string, fieldValue reflect.Value, args []string) (map[string][]string, error) {
+		value, found := formatValue(fieldName, values, defaultValue, fieldValue)
+		if !found {
+			//nolint:nilnil // nil, nil means no validation and internal errors
+			return nil, nil
+		}
+
+		ok, err := valid(value, args)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			//nolint:nilnil // nil, nil means no validation and internal errors
+			return nil, nil
+		}
+
+		return map[string][]string{
+			fieldName: {message},
+		}, nil
+	}
+}
+
+// formatValue is the text a format rule checks: the input, else the default,
+// else the field's current value when it is a string or a number.
+func formatValue(fieldName string, values map[string]any, defaultValue string, fieldValue reflect.Value) (string, bool) {
+	value := ""
+	if raw, ok := values[fieldName]; ok && raw != nil {
+		if s, isStr := raw.(string); isStr {
+			value = strings.TrimSpace(s)
+		} else {
+			value = fmt.Sprintf("%v", raw)
+		}
+	}
+	if value == "" {
+		value = defaultValue
+	}
+	if value == "" && fieldValue.IsValid() && !fieldValue.IsZero() {
+		current := reflect.Indirect(fieldValue)
+		if current.Kind() == reflect.String || isNumericKind(current.Kind()) {
+			value = fmt.Sprintf("%v", current.Interface())
+		}
+	}
+
+	return value, value != ""
+}
+
+func isHostname(value string) bool {
+	value = strings.TrimSuffix(value, ".")
+	if value == "" || len(value) > 253 {
+		return false
+	}
+
+	for _, label := range strings.Split(value, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, char := range label {
+			isAlnum := char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' || char >= '0' && char <= '9'
+			if !isAlnum && char != '-' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,149 @@
+package structs
+
+import (
+	"testing"
+)
+
+func Test_Validate_Formats(t *testing.T) {
+	tests := []struct {
+		rule    string
+		valid   []any
+		invalid []any
+		message string
+	}{
+		{
+			rule:    "email",
+			valid:   []any{"ops@example.com", "first.last+tag@sub.example.org"},
+			invalid: []any{"ops", "ops@", "Ops <ops@example.com>", "@example.com"},
+			message: "must be a valid email address",
+		},
+		{
+			rule:    "url",
+			valid:   []any{"https://example.com/path?q=1", "ftp://files.example.com"},
+			invalid: []any{"example.com", "/relative/path", "https://", "::"},
+			message: "must be a valid URL",
+		},
+		{
+			rule:    "url:http,https",
+			valid:   []any{"http://example.com", "HTTPS://example.com"},
+			invalid: []any{"ftp://example.com", "postgres://db/app"},
+			message: "must be a valid URL with scheme: http, https",
+		},
+		{
+			rule:    "uuid",
+			valid:   []any{"123e4567-e89b-12d3-a456-426614174000", "123E4567-E89B-12D3-A456-426614174000"},
+			invalid: []any{"123e4567e89b12d3a456426614174000", "123e4567-e89b-12d3-a456-42661417400z"},
+			message: "must be a valid UUID",
+		},
+		{
+			rule:    "ip",
+			valid:   []any{"192.168.0.1", "::1", "fe80::1"},
+			invalid: []any{"256.0.0.1", "localhost", "10.0.0.0/8"},
+			message: "must be a valid IP address",
+		},
+		{
+			rule:    "ipv4",
+			valid:   []any{"10.0.0.1"},
+			invalid: []any{"::1", "10.0.0"},
+			message: "must be a valid IPv4 address",
+		},
+		{
+			rule:    "ipv6",
+			valid:   []any{"2001:db8::1", "::ffff:10.0.0.1"},
+			invalid: []any{"10.0.0.1", "2001:db8::g"},
+			message: "must be a valid IPv6 address",
+		},
+		{
+			rule:    "cidr",
+			valid:   []any{"10.0.0.0/8", "2001:db8::/32"},
+			invalid: []any{"10.0.0.1", "10.0.0.0/33"},
+			message: "must be a valid CIDR",
+		},
+		{
+			rule:    "hostname",
+			valid:   []any{"localhost", "api-1.example.com", "example.com."},
+			invalid: []any{"-api.example.com", "api_1.example.com", "a..b", "example.com..", "bad host"},
+			message: "must be a valid hostname",
+		},
+		{
+			rule:    "port",
+			valid:   []any{"80", 443, 65535},
+			invalid: []any{"0", 65536, "http", -1},
+			message: "must be a valid port",
+		},
+		{
+			rule:    "regex:^[a-z]+$",
+			valid:   []any{"abc"},
+			invalid: []any{"abc1", "ABC"},
+			message: "must match ^[a-z]+$",
+		},
+		{
+			rule:    "regex:'^[a-z]{2,3}(-[A-Z]{2}|)$'",
+			valid:   []any{"en", "pt-BR", "fil"},
+			invalid: []any{"e", "english", "pt-br"},
+			message: "must match ^[a-z]{2,3}(-[A-Z]{2}|)$",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			field := Field{Name: "value", Rules: parseRules(splitQuoted(tt.rule, '|'))}
+			for _, value := range tt.valid {
+				errs, err := ValidateStructFields(DefaultRules, []Field{field}, map[string]any{"value": value}, "rules")
+				requireNoError(t, err)
+				requireEqual(t, map[string][]string{}, errs, value)
+			}
+			for _, value := range tt.invalid {
+				errs, err := ValidateStructFields(DefaultRules, []Field{field}, map[string]any{"value": value}, "rules")
+				requireNoError(t, err)
+				requireEqual(t, map[string][]string{"value": {tt.message}}, errs, value)
+			}
+
+			// like oneof, an absent or empty value is left to required
+			errs, err := ValidateStructFields(DefaultRules, []Field{field}, map[string]any{"value": " "}, "rules")
+			requireNoError(t, err)
+			requireEqual(t, map[string][]string{}, errs)
+		})
+	}
+}
+
+func Test_Validate_FormatsOnSetValues(t *testing.T) {
+	type target struct {
+		Email string  `json:"email" rules:"email"`
+		Port  *uint16 `json:"port" rules:"port"`
+		Host  string  `json:"host" default:"bad host" rules:"hostname"`
+	}
+
+	port := uint16(0)
+	errs, err := New(&target{Email: "nope", Port: &port}, WithTags("json")).Validate(map[string]any{})
+	requireNoError(t, err)
+	// a set pointer is checked even when it points at zero, and the default
+	// is checked when there is no input
+	requireEqual(t, map[string][]string{
+		"email": {"must be a valid email address"},
+		"port":  {"must be a valid port"},
+		"host":  {"must be a valid hostname"},
+	}, errs)
+}
+
+func Test_Validate_RegexErrors(t *testing.T) {
+	for _, rule := range []string{"regex", "regex:[a-z", "regex:a,b"} {
+		field := Field{Name: "value", Rules: parseRules(splitQuoted(rule, '|'))}
+		_, err := ValidateStructFields(DefaultRules, []Field{field}, map[string]any{"value": "a"}, "rules")
+		if err == nil {
+			t.Fatalf("%s: expected a rule error", rule)
+		}
+	}
+}
+
+func Test_Validate_RegexUnquotedComma(t *testing.T) {
+	type target struct {
+		Code string `json:"code" rules:"regex:^a{2,8}$"`
+	}
+	fields, err := GetStructFields(&target{}, nil, DefaultEncodingTags)
+	requireNoError(t, err)
+	_, err = ValidateStructFields(DefaultRules, fields, map[string]any{"code": "aaa"}, "rules")
+	requireErrorContains(t, err, "field 'Code'")
+	requireErrorContains(t, err, "single-quote")
+	requireErrorContains(t, err, "regex:'^a{2,8}$'")
+}
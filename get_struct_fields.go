@@ -39,7 +39,7 @@ func GetStructFields(structure any, parent *Field, encodingTags []string) ([]Fie
 		// struct field) instead groups its fields under a dotted FQN, matching
 		// encoding/json: a tag on an anonymous field names it rather than
 		// promoting it.
-		if field.Anonymous && field.Type.Kind() == reflect.Struct && len(tags) == 0 {
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && isNestedStruct(field.Type) && len(tags) == 0 {
 			promoted, err := GetStructFields(addrInterface(fieldValue), parent, encodingTags)
 			if err != nil {
 				return nil, err
@@ -50,8 +50,20 @@ func GetStructFields(structure any, parent *Field, encodingTags []string) ([]Fie
 
 		f := NewField(field.Name, field.Type.Kind(), fieldValue, tags, parent)
 
-		if field.Type.Kind() == reflect.Struct {
-			nestedFields, err := GetStructFields(addrInterface(fieldValue), &f, encodingTags)
+		if isNestedStruct(field.Type) && !hasAncestorType(parent, field.Type) {
+			// a pointer-to-struct nests like a value struct. a nil one has its
+			// fields staged in a freshly allocated struct that SetFields only
+			// attaches once something was set on it, so untouched pointers stay nil.
+			target := fieldValue
+			if field.Type.Kind() == reflect.Pointer {
+				if fieldValue.IsNil() {
+					f.pending = reflect.New(field.Type.Elem())
+					target = f.pending.Elem()
+				} else {
+					target = fieldValue.Elem()
+				}
+			}
+			nestedFields, err := GetStructFields(addrInterface(target), &f, encodingTags)
 			if err != nil {
 				return nil, err
 			}
@@ -68,6 +80,28 @@ func GetStructFields(structure any, parent *Field, encodingTags []string) ([]Fie
 	return fields, nil
 }
 
+// isNestedStruct reports whether typ is a struct, or a pointer to one, whose
+// fields should be reflected as nested Fields. Struct types that unmarshal
+// themselves (netip.Addr, big.Int, ...) are leaves, set as a whole.
+func isNestedStruct(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Struct && !hasUnmarshaler(typ)
+}
+
+// hasAncestorType reports whether parent or any of its ancestors has type typ.
+// It stops a self-referential pointer (type Node struct{ Next *Node }) from
+// being staged and expanded forever.
+func hasAncestorType(parent *Field, typ reflect.Type) bool {
+	for ; parent != nil; parent = parent.Parent {
+		if parent.Value.IsValid() && parent.Value.Type() == typ {
+			return true
+		}
+	}
+	return false
+}
+
 // addrInterface returns an interfaceable pointer to v, which must be
 // addressable. reflect refuses Addr().Interface() on an unexported field (e.g.
 // an embedded struct whose type is unexported), so the pointer is rebuilt via
@@ -241,6 +241,25 @@ func Test_GetStructFields_TaggedEmbedGroups(t *testing.T) {
 	requireEqual(t, "nested.alpha", alpha.FQN.Tags["json"], "FQN json tag")
 }
 
+// a pointer-to-struct field nests like a value struct: its fields carry a
+// dotted FQN, and reflecting over a nil pointer does not allocate it.
+func Test_GetStructFields_PointerStruct(t *testing.T) {
+	s := &ptrServer{}
+	fields, err := GetStructFields(s, nil, DefaultEncodingTags)
+	requireNoError(t, err)
+
+	requireLen(t, fields, 4)
+	database := fields[3]
+	requireEqual(t, "ptr", database.Type, "Type")
+	requireLen(t, database.Fields, 2)
+	requireNotNil(t, database.Fields[0].FQN, "FQN")
+	requireEqual(t, "database.url", database.Fields[0].FQN.Tags["json"], "FQN json tag")
+	requireEqual(t, "DATABASE_URL", database.Fields[0].FQN.Tags["env"], "FQN env tag")
+	if s.Database != nil {
+		t.Fatalf("GetStructFields allocated a nil pointer field")
+	}
+}
+
 func Test_parseTags(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -285,3 +304,48 @@ func Test_parseTags(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []Rule
+	}{
+		{
+			name:     "names and args",
+			input:    "required|oneof:json,yaml",
+			expected: []Rule{{Name: "required"}, {Name: "oneof", Args: []string{"json", "yaml"}}},
+		},
+		{
+			name:     "args keep colons after the first",
+			input:    "regex:^a:b$",
+			expected: []Rule{{Name: "regex", Args: []string{"^a:b$"}}},
+		},
+		{
+			name:     "quoted args keep separators",
+			input:    "regex:'^(a|b),c$'|required",
+			expected: []Rule{{Name: "regex", Args: []string{"^(a|b),c$"}}, {Name: "required"}},
+		},
+		{
+			name:     "quoted and plain args mix",
+			input:    "oneof:'a,b',c",
+			expected: []Rule{{Name: "oneof", Args: []string{"a,b", "c"}}},
+		},
+		{
+			name:     "doubled quotes inside quotes are literal",
+			input:    "regex:'^it''s$'",
+			expected: []Rule{{Name: "regex", Args: []string{"^it's$"}}},
+		},
+		{
+			name:     "a stray apostrophe is not a quote",
+			input:    "oneof:don't,won't|required",
+			expected: []Rule{{Name: "oneof", Args: []string{"don't", "won't"}}, {Name: "required"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requireEqual(t, tt.expected, parseRules(splitQuoted(tt.input, '|')))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package structs
+
+import (
+	"reflect"
+	"strings"
+)
+
+// GetStructValues is the reverse of SetStructFields: it reflects over
+// structure (a pointer to a struct) and returns its field values as a map keyed
+// the way SetStructFields looks them up, by the first tag in
+// settings.TagOrder a field carries, falling back to its Go field name.
+//
+// Nested struct fields are returned as nested map[string]any sections
+// ({"database": {"dsn": ...}}), or, when flatten is true, under their dotted
+// FQN key ({"database.dsn": ...}). Either shape fed back to SetStructFields
+// with the same settings produces an identical struct. Nested fields keyed by
+// their Go name are always flat ("Database.DSN"), since that is the only form
+// SetStructFields matches them by.
+//
+// Unexported fields, fields tagged "-", nil pointer fields and the fields of
+// nil pointer-to-struct fields are skipped; a non-nil pointer is dereferenced.
+func GetStructValues(structure any, settings Settings, flatten bool) (map[string]any, error) {
+	fields, err := GetStructFields(structure, nil, settings.EncodingTags)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]any)
+	mapFieldValues(fields, settings, flatten, values)
+
+	return values, nil
+}
+
+func mapFieldValues(fields []Field, settings Settings, flatten bool, values map[string]any) {
+	for _, field := range fields {
+		if !field.Value.IsValid() || !field.Value.CanInterface() {
+			continue
+		}
+
+		if field.Fields != nil && !settings.hasConverter(field.Value.Type()) {
+			// a nil pointer-to-struct: its fields were staged, there's nothing set
+			if field.pending.IsValid() {
+				continue
+			}
+			mapFieldValues(field.Fields, settings, flatten, values)
+			continue
+		}
+
+		key, byTag := fieldKey(field, settings.TagOrder)
+		if key == "-" {
+			continue
+		}
+
+		value := field.Value.Interface()
+		if field.Kind == reflect.Pointer {
+			// a typed nil would not set back, and an absent key leaves it nil
+			if field.Value.IsNil() {
+				continue
+			}
+			value = field.Value.Elem().Interface()
+		}
+
+		if flatten || !byTag {
+			values[key] = value
+			continue
+		}
+		setNestedValue(values, strings.Split(key, "."), value)
+	}
+}
+
+// fieldKey returns the input key SetField matches field by: the first tag in
+// tagOrder the field (or, when nested, its FQN) carries, else its Go name.
+// byTag reports which of the two it is.
+func fieldKey(field Field, tagOrder []string) (string, bool) {
+	named := field
+	if field.FQN != nil {
+		named = *field.FQN
+	}
+
+	if key := getTagByPriority(named.Tags, tagOrder); key != "" {
+		return key, true
+	}
+
+	return named.Name, false
+}
+
+// setNestedValue stores value at path inside values, creating the
+// intermediate map[string]any sections findNestedValue descends through. A
+// path whose intermediate key already holds a non-map value is stored flat
+// under its dotted key instead.
+func setNestedValue(values map[string]any, path []string, value any) {
+	current := values
+	for _, key := range path[:len(path)-1] {
+		existing, ok := current[key]
+		if !ok {
+			nested := make(map[string]any)
+			current[key] = nested
+			current = nested
+			continue
+		}
+
+		nested, ok := existing.(map[string]any)
+		if !ok {
+			values[strings.Join(path, ".")] = value
+			return
+		}
+		current = nested
+	}
+
+	current[path[len(path)-1]] = value
+}
@@ -0,0 +1,134 @@
+package structs
+
+import (
+	"testing"
+	"time"
+)
+
+type valuesDatabase struct {
+	DSN  string `json:"dsn" env:"DSN"`
+	Pool int    `json:"pool"`
+}
+
+type valuesCache struct {
+	TTL time.Duration `json:"ttl"`
+}
+
+type valuesConfig struct {
+	Host     string   `json:"host"`
+	Port     uint16   `json:"port"`
+	Tags     []string `json:"tags"`
+	Name     *string  `json:"name"`
+	Untagged string
+	Skipped  string          `json:"-"`
+	Database valuesDatabase  `json:"database" env:"DATABASE"`
+	Cache    *valuesCache    `json:"cache"`
+	Replica  *valuesDatabase `json:"replica"`
+	internal string
+}
+
+func populatedValuesConfig() *valuesConfig {
+	name := "edge"
+	return &valuesConfig{
+		Host:     "127.0.0.1",
+		Port:     8080,
+		Tags:     []string{"a", "b"},
+		Name:     &name,
+		Untagged: "plain",
+		Database: valuesDatabase{DSN: "postgres://", Pool: 4},
+		Cache:    &valuesCache{TTL: time.Minute},
+		internal: "hidden",
+	}
+}
+
+func Test_GetStructValues(t *testing.T) {
+	settings := Settings{TagOrder: DefaultTags, EncodingTags: DefaultEncodingTags}
+
+	t.Run("nested sections", func(t *testing.T) {
+		got, err := GetStructValues(populatedValuesConfig(), settings, false)
+		requireNoError(t, err)
+		requireEqual(t, map[string]any{
+			"host":     "127.0.0.1",
+			"port":     uint16(8080),
+			"tags":     []string{"a", "b"},
+			"name":     "edge",
+			"Untagged": "plain",
+			"database": map[string]any{"dsn": "postgres://", "pool": 4},
+			"cache":    map[string]any{"ttl": time.Minute},
+		}, got)
+	})
+
+	t.Run("flattened dotted keys", func(t *testing.T) {
+		got, err := GetStructValues(populatedValuesConfig(), settings, true)
+		requireNoError(t, err)
+		requireEqual(t, map[string]any{
+			"host":          "127.0.0.1",
+			"port":          uint16(8080),
+			"tags":          []string{"a", "b"},
+			"name":          "edge",
+			"Untagged":      "plain",
+			"database.dsn":  "postgres://",
+			"database.pool": 4,
+			"cache.ttl":     time.Minute,
+		}, got)
+	})
+
+	for name, flatten := range map[string]bool{"nested": false, "flattened": true} {
+		t.Run(name+" round-trips through SetStructFields", func(t *testing.T) {
+			want := populatedValuesConfig()
+			values, err := GetStructValues(want, settings, flatten)
+			requireNoError(t, err)
+
+			got := &valuesConfig{}
+			err = SetStructFields(got, settings, values)
+			requireNoError(t, err)
+
+			want.internal = ""
+			requireEqual(t, want, got)
+		})
+	}
+
+	t.Run("non-pointer structure errors", func(t *testing.T) {
+		_, err := GetStructValues(valuesConfig{}, settings, false)
+		requireErrorIs(t, err, ErrInputPointer)
+	})
+}
+
+func Test_Struct_Map(t *testing.T) {
+	type target struct {
+		Name string `yaml:"name"`
+		Port int    `json:"port" default:"8080"`
+	}
+	got := &target{}
+	s := New(got)
+	requireNoError(t, s.Set(map[string]any{"name": "svc"}))
+
+	values, err := s.Map(true)
+	requireNoError(t, err)
+	requireEqual(t, map[string]any{"name": "svc", "port": 8080}, values)
+}
+
+func Test_Struct_MapSetRoundTrip(t *testing.T) {
+	type target struct {
+		Name  *string `json:"name"`
+		Port  *int    `json:"port"`
+		Label *string `json:"label"`
+		Limit *int    `json:"limit"`
+	}
+	name, port := "svc", 8080
+	original := &target{Name: &name, Port: &port}
+
+	values, err := New(original).Map(false)
+	requireNoError(t, err)
+	requireEqual(t, map[string]any{"name": "svc", "port": 8080}, values)
+
+	got := &target{}
+	requireNoError(t, New(got).Set(values))
+	requireNotNil(t, got.Name)
+	requireEqual(t, "svc", *got.Name)
+	requireNotNil(t, got.Port)
+	requireEqual(t, 8080, *got.Port)
+	if got.Label != nil || got.Limit != nil {
+		t.Fatalf("expected nil pointers to stay nil, got %+v", got)
+	}
+}
@@ -0,0 +1,209 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrValidation is wrapped by the error Loader.Load returns when the merged
+// inputs fail validation.
+var ErrValidation = errors.New("validation failed")
+
+// Source is one layer of inputs for a Loader: a map, the environment, the
+// command line, a config file, or anything else that can produce a
+// map[string]any.
+type Source struct {
+	// Name identifies the source in errors, e.g. "env" or "config.yaml".
+	Name string
+	// Tags, when set, replaces the Struct's tag priority for matching this
+	// source's keys to fields, e.g. arg/short for command-line inputs.
+	Tags []string
+	// Load returns the source's inputs for structure, the pointer to the
+	// struct being loaded.
+	Load func(structure any) (map[string]any, error)
+}
+
+// MapSource is a Source returning values as-is, e.g. hard-coded defaults or
+// an already-decoded config.
+func MapSource(name string, values map[string]any) Source {
+	return Source{
+		Name: name,
+		Load: func(any) (map[string]any, error) { return values, nil },
+	}
+}
+
+// EnvSource is a Source reading the env vars the struct's `env:` tags name,
+// each with prefix prepended. See FromEnv.
+func EnvSource(prefix string) Source {
+	return Source{
+		Name: "env",
+		Load: func(structure any) (map[string]any, error) { return FromEnv(structure, prefix) },
+	}
+}
+
+// ArgsSource is a Source parsing command-line args against the struct's
+// `arg:` and `short:` tags. Positional args are dropped; call ParseArgs
+// directly when you need them. See ParseArgs.
+func ArgsSource(args []string) Source {
+	return Source{
+		Name: "args",
+		Tags: []string{argTag, shortTag},
+		Load: func(structure any) (map[string]any, error) {
+			inputs, _, err := ParseArgs(structure, args)
+			return inputs, err
+		},
+	}
+}
+
+// FileSource is a Source reading the config file at path and decoding it into
+// a map[string]any with decode, e.g. json.Unmarshal or yaml.Unmarshal.
+func FileSource(path string, decode func(data []byte, v any) error) Source {
+	return Source{
+		Name: path,
+		Load: func(any) (map[string]any, error) {
+			data, err := os.ReadFile(path) //nolint:gosec // reading the caller's chosen config file is the point
+			if err != nil {
+				return nil, err
+			}
+			values := make(map[string]any)
+			if err := decode(data, &values); err != nil {
+				return nil, fmt.Errorf("failed to decode: %w", err)
+			}
+			return values, nil
+		},
+	}
+}
+
+// Loader merges an ordered list of sources into a bound Struct, then validates
+// and sets it in one call. Later sources take precedence over earlier ones,
+// field by field: each source's value for a field is found the way Set finds
+// it (env key, dotted key, nested map section or field name), so a later
+// "database.dsn" overrides an earlier {"database": {"dsn": ...}} section.
+type Loader struct {
+	structure *Struct
+	sources   []Source
+	// origins maps each merged key to the source and key it came from.
+	origins map[string]Origin
+}
+
+// NewLoader binds sources, lowest precedence first, to s. A typical order is
+// defaults, config file, env, args.
+func NewLoader(s *Struct, sources ...Source) *Loader {
+	return &Loader{structure: s, sources: sources}
+}
+
+// Merge loads every source and merges their values into one inputs map keyed
+// by each field's tag-priority key (flattened, e.g. "database.dsn"). Inputs
+// that match no field are dropped, or, for a Struct built WithStrict,
+// reported per source. Every failing source is reported.
+func (l *Loader) Merge() (map[string]any, error) {
+	fields, err := GetStructFields(l.structure.structure, nil, l.structure.encodingTags)
+	if err != nil {
+		return nil, fmt.Errorf("error getting struct fields for loading: %w", err)
+	}
+
+	merged := make(map[string]any)
+	l.origins = make(map[string]Origin)
+	var errs []error
+	for _, source := range l.sources {
+		values, err := source.Load(l.structure.structure)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error loading source %s: %w", source.Name, err))
+			continue
+		}
+
+		tags := source.Tags
+		if tags == nil {
+			tags = l.structure.tags
+		}
+		if l.structure.strict {
+			settings := l.structure.settings()
+			settings.TagOrder = tags
+			if unknown := unknownInputKeys(fields, settings, values); len(unknown) > 0 {
+				errs = append(errs, fmt.Errorf("error loading source %s: %w", source.Name, &UnknownKeysError{Keys: unknown}))
+				continue
+			}
+		}
+		mergeFieldInputs(fields, l.structure.settings(), source.Name, values, tags, l.structure.tags, merged, l.origins)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return merged, nil
+}
+
+// mergeFieldInputs copies each field's value found in values (matched by
+// sourceTags) into merged under the field's key by tagOrder, overriding what an
+// earlier source put there, and notes the source and key in origins.
+func mergeFieldInputs(fields []Field, settings Settings, sourceName string, values map[string]any, sourceTags, tagOrder []string, merged map[string]any, origins map[string]Origin) {
+	for _, field := range fields {
+		if field.Fields != nil && !settings.hasConverter(field.Value.Type()) {
+			mergeFieldInputs(field.Fields, settings, sourceName, values, sourceTags, tagOrder, merged, origins)
+			continue
+		}
+
+		named := field
+		if field.FQN != nil {
+			named = *field.FQN
+		}
+		sourceKey, value, found := findFieldInput(named, values, sourceTags)
+		if !found {
+			continue
+		}
+
+		key, _ := fieldKey(field, tagOrder)
+		merged[key] = value
+		match, tag := matchedBy(named, sourceKey, sourceTags)
+		origins[key] = Origin{Source: sourceName, Match: match, Key: sourceKey, Tag: tag}
+	}
+}
+
+// matchedBy reports how findFieldInput matched key to named, and by which tag.
+func matchedBy(named Field, key string, sourceTags []string) (Match, string) {
+	if named.Tags[envValueTag] == key {
+		return MatchEnv, envValueTag
+	}
+	for _, tag := range sourceTags {
+		if named.Tags[tag] == key {
+			return MatchTag, tag
+		}
+	}
+	return MatchName, ""
+}
+
+// Load merges the sources, validates the result and sets the struct. Source,
+// validation and set failures come back as one error; validation failures
+// wrap both ErrValidation and the ValidationErrors listing every failed rule.
+func (l *Loader) Load() error {
+	inputs, err := l.Merge()
+	if err != nil {
+		return err
+	}
+
+	err = l.structure.Check(inputs)
+	var validationErrors ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return fmt.Errorf("%w: %w", ErrValidation, validationErrors)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = l.structure.Set(inputs)
+	if err != nil {
+		return err
+	}
+
+	// Set saw only the merged keys: point each recorded origin back at the
+	// source and key its input actually came from.
+	for path, origin := range l.structure.provenance {
+		if from, ok := l.origins[origin.Key]; ok && origin.Match != MatchDefault {
+			from.Input = origin.Input
+			l.structure.provenance[path] = from
+		}
+	}
+
+	return nil
+}
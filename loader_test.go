@@ -0,0 +1,85 @@
+package structs
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type loaderDatabase struct {
+	DSN  string `json:"dsn" arg:"dsn" env:"DSN" rules:"required"`
+	Pool int    `json:"pool" arg:"pool"`
+}
+
+type loaderConfig struct {
+	Host     string         `json:"host" arg:"host" short:"H" env:"HOST" default:"0.0.0.0"`
+	Port     int            `json:"port" arg:"port" env:"PORT" rules:"required"`
+	Mode     string         `json:"mode" arg:"mode" rules:"oneof:dev,prod"`
+	Database loaderDatabase `json:"database" arg:"database" env:"DATABASE"`
+}
+
+func writeLoaderFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+func Test_Loader(t *testing.T) {
+	path := writeLoaderFile(t, `{"host": "file-host", "port": 1000, "mode": "dev", "database": {"dsn": "file-dsn", "pool": 2}}`)
+
+	t.Run("later sources override earlier ones field by field", func(t *testing.T) {
+		t.Setenv("MYAPP_PORT", "2000")
+		t.Setenv("MYAPP_DATABASE_DSN", "env-dsn")
+
+		cfg := &loaderConfig{}
+		err := NewLoader(New(cfg),
+			MapSource("defaults", map[string]any{"mode": "prod", "database.pool": 1}),
+			FileSource(path, json.Unmarshal),
+			EnvSource("MYAPP_"),
+			ArgsSource([]string{"-H", "args-host", "--database.pool", "8"}),
+		).Load()
+		requireNoError(t, err)
+		requireEqual(t, &loaderConfig{
+			Host:     "args-host",
+			Port:     2000,
+			Mode:     "dev",
+			Database: loaderDatabase{DSN: "env-dsn", Pool: 8},
+		}, cfg)
+	})
+
+	t.Run("a later dotted key overrides an earlier nested section", func(t *testing.T) {
+		merged, err := NewLoader(New(&loaderConfig{}),
+			MapSource("file", map[string]any{"database": map[string]any{"dsn": "section", "pool": 3}}),
+			MapSource("override", map[string]any{"database.dsn": "dotted"}),
+		).Merge()
+		requireNoError(t, err)
+		requireEqual(t, map[string]any{"database.dsn": "dotted", "database.pool": 3}, merged)
+	})
+
+	t.Run("validation failures are combined into one error", func(t *testing.T) {
+		err := NewLoader(New(&loaderConfig{}),
+			MapSource("defaults", map[string]any{"mode": "staging"}),
+		).Load()
+		requireErrorIs(t, err, ErrValidation)
+		requireErrorContains(t, err, "port: required; mode: must be one of: dev, prod; database.dsn: required")
+		var validationErrors ValidationErrors
+		if !errors.As(err, &validationErrors) {
+			t.Fatalf("expected ValidationErrors in %v", err)
+		}
+		requireLen(t, validationErrors, 3)
+	})
+
+	t.Run("every failing source is reported", func(t *testing.T) {
+		err := NewLoader(New(&loaderConfig{}),
+			FileSource(filepath.Join(t.TempDir(), "missing.json"), json.Unmarshal),
+			ArgsSource([]string{"--nope"}),
+		).Load()
+		requireErrorIs(t, err, os.ErrNotExist)
+		requireErrorIs(t, err, ErrUnknownFlag)
+	})
+}
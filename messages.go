@@ -0,0 +1,91 @@
+package structs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Messages maps a rule name to the message template reported when it fails.
+// A template may interpolate:
+//
+//	{field}  the name the failure is reported under, e.g. "database.dsn"
+//	{rule}   the rule name, e.g. "oneof"
+//	{args}   the rule args joined with ", ", e.g. "json, yaml"
+//	{0}, {1} a single rule arg by position
+//	{value}  the offending input (or default), empty when there was none
+type Messages map[string]string
+
+// MessageCatalog maps a locale (e.g. "en", "de") to its Messages.
+type MessageCatalog map[string]Messages
+
+// DefaultLocale is the locale used when none is selected, and the fallback for
+// rules a selected locale has no message for.
+const DefaultLocale = "en"
+
+// DefaultMessages is the built-in catalog. It reproduces the messages the
+// built-in rules report, so selecting it changes nothing; extend a copy (or
+// pass your own locales to WithMessages) to translate them. A rule with no
+// template keeps the message it reports itself: min and max word theirs by
+// the field's Kind, url by its allowed schemes, the required_* rules by
+// their condition.
+var DefaultMessages = MessageCatalog{
+	DefaultLocale: {
+		"required": "required",
+		"oneof":    "must be one of: {args}",
+		"email":    "must be a valid email address",
+		"uuid":     "must be a valid UUID",
+		"ip":       "must be a valid IP address",
+		"ipv4":     "must be a valid IPv4 address",
+		"ipv6":     "must be a valid IPv6 address",
+		"cidr":     "must be a valid CIDR",
+		"hostname": "must be a valid hostname",
+		"port":     "must be a valid port",
+		"regex":    "must match {0}",
+		"eqfield":  "must equal {0}",
+	},
+}
+
+// messages resolves the Messages for locale from catalog: the locale's own
+// templates, then its base language ("pt" for "pt-BR"), then DefaultLocale.
+func (c MessageCatalog) messages(locale string) Messages {
+	resolved := make(Messages)
+	locales := []string{DefaultLocale}
+	if base, _, ok := strings.Cut(locale, "-"); ok {
+		locales = append(locales, base)
+	}
+	locales = append(locales, locale)
+
+	// later locales win, so the most specific template is kept
+	for _, l := range locales {
+		for rule, template := range c[l] {
+			resolved[rule] = template
+		}
+	}
+
+	return resolved
+}
+
+// renderMessage fills a message template from a failed rule.
+func renderMessage(template string, fieldError FieldError) string {
+	if !strings.Contains(template, "{") {
+		return template
+	}
+
+	value := ""
+	if fieldError.Value != nil {
+		value = fmt.Sprintf("%v", fieldError.Value)
+	}
+
+	replacements := []string{
+		"{field}", fieldError.Field,
+		"{rule}", fieldError.Rule,
+		"{args}", strings.Join(fieldError.Args, ", "),
+		"{value}", value,
+	}
+	for i, arg := range fieldError.Args {
+		replacements = append(replacements, "{"+strconv.Itoa(i)+"}", arg)
+	}
+
+	return strings.NewReplacer(replacements...).Replace(template)
+}
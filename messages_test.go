@@ -0,0 +1,97 @@
+package structs
+
+import (
+	"testing"
+)
+
+type messagesConfig struct {
+	Format string `json:"format" rules:"required|oneof:json,yaml"`
+	Port   int    `json:"port" rules:"required" msg:"{field} must be set"`
+}
+
+var germanMessages = MessageCatalog{
+	"de": {
+		"required": "erforderlich",
+		"oneof":    "{field} muss eines von {args} sein, nicht {value}",
+	},
+}
+
+func Test_Struct_Messages(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		values   map[string]any
+		expected map[string][]string
+	}{
+		{
+			name:   "default messages are unchanged",
+			values: map[string]any{"format": "xml", "port": 80},
+			expected: map[string][]string{
+				"format": {"must be one of: json, yaml"},
+			},
+		},
+		{
+			name:   "msg tag overrides the rule message",
+			values: map[string]any{"format": "json"},
+			expected: map[string][]string{
+				"port": {"port must be set"},
+			},
+		},
+		{
+			name:   "selected locale interpolates field, args and value",
+			opts:   []Option{WithMessages(germanMessages), WithLocale("de")},
+			values: map[string]any{"format": "xml"},
+			expected: map[string][]string{
+				"format": {"format muss eines von json, yaml sein, nicht xml"},
+				"port":   {"port must be set"},
+			},
+		},
+		{
+			name:   "regional locale falls back to its base language",
+			opts:   []Option{WithMessages(germanMessages), WithLocale("de-AT")},
+			values: map[string]any{"port": 80},
+			expected: map[string][]string{
+				"format": {"erforderlich"},
+			},
+		},
+		{
+			name: "rules missing from a locale fall back to the default locale",
+			opts: []Option{
+				WithMessages(MessageCatalog{"fr": {"required": "obligatoire"}}),
+				WithLocale("fr"),
+			},
+			values: map[string]any{"format": "xml"},
+			expected: map[string][]string{
+				"format": {"must be one of: json, yaml"},
+				"port":   {"port must be set"},
+			},
+		},
+		{
+			name:   "unknown locale uses the default locale",
+			opts:   []Option{WithLocale("xx")},
+			values: map[string]any{},
+			expected: map[string][]string{
+				"format": {"required"},
+				"port":   {"port must be set"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithTags("json")}, tt.opts...)
+			errs, err := New(&messagesConfig{}, opts...).Validate(tt.values)
+			requireNoError(t, err)
+			requireEqual(t, tt.expected, errs)
+		})
+	}
+}
+
+func Test_renderMessage(t *testing.T) {
+	fieldError := FieldError{Field: "mode", Rule: "oneof", Args: []string{"dev", "prod"}, Value: 3}
+
+	requireEqual(t, "mode (oneof): 3 not in dev, prod; first is dev",
+		renderMessage("{field} ({rule}): {value} not in {args}; first is {0}", fieldError))
+	requireEqual(t, "no placeholders", renderMessage("no placeholders", fieldError))
+	requireEqual(t, "got ", renderMessage("got {value}", FieldError{}))
+}
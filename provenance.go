@@ -0,0 +1,84 @@
+package structs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Match is how SetField matched an input to a field.
+type Match string
+
+const (
+	// MatchDefault means the field's `default:` tag value was applied.
+	MatchDefault Match = "default"
+	// MatchEnv means the input was found under the field's env tag.
+	MatchEnv Match = "env"
+	// MatchName means the input was found under the field's Go name.
+	MatchName Match = "name"
+	// MatchTag means the input was found under one of the TagOrder tags,
+	// directly or inside a nested map section.
+	MatchTag Match = "tag"
+)
+
+// Origin records where a field's value came from.
+type Origin struct {
+	// Source is the Loader source that provided the input (e.g. "env",
+	// "config.yaml"); empty when Set was called directly.
+	Source string
+	// Match is how the input was matched to the field.
+	Match Match
+	// Key is the input key that matched, e.g. "DATABASE_DSN" or "database.dsn".
+	// Empty for defaults.
+	Key string
+	// Tag is the tag whose value matched (e.g. "env", "json"), "default" for
+	// defaults, and empty for a Go name match.
+	Tag string
+	// Input is the raw input value, before conversion.
+	Input any
+}
+
+// Provenance maps each field's Go path (e.g. "Database.DSN") to the Origin of
+// the value it was last set from. Fields nothing set are absent.
+type Provenance map[string]Origin
+
+// String renders one "path = input (source)" line per field, sorted by path,
+// suitable for a --print-config command.
+func (p Provenance) String() string {
+	paths := make([]string, 0, len(p))
+	for path := range p {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var b strings.Builder
+	for _, path := range paths {
+		origin := p[path]
+		from := string(origin.Match)
+		if origin.Key != "" {
+			from += " " + origin.Key
+		}
+		if origin.Source != "" {
+			from = origin.Source + ": " + from
+		}
+		fmt.Fprintf(&b, "%s = %v (%s)\n", path, origin.Input, from)
+	}
+
+	return b.String()
+}
+
+// record stores origin as field's provenance, if recording is enabled.
+func (s Settings) record(field Field, origin Origin) {
+	if s.Provenance == nil {
+		return
+	}
+	s.Provenance[fieldPath(field)] = origin
+}
+
+// fieldPath is field's Go path: its FQN name when nested, else its name.
+func fieldPath(field Field) string {
+	if field.FQN != nil {
+		return field.FQN.Name
+	}
+	return field.Name
+}
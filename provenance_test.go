@@ -0,0 +1,77 @@
+package structs
+
+import (
+	"testing"
+)
+
+type provenanceDatabase struct {
+	DSN  string `json:"dsn" env:"DSN"`
+	Pool int    `json:"pool" default:"4"`
+}
+
+type provenanceConfig struct {
+	Host     string             `json:"host" default:"0.0.0.0"`
+	Port     int                `json:"port" env:"PORT"`
+	Mode     string             `json:"mode"`
+	Name     string             `json:"name"`
+	Unset    string             `json:"unset"`
+	Database provenanceDatabase `json:"database" env:"DATABASE"`
+}
+
+func Test_SetField_Provenance(t *testing.T) {
+	settings := Settings{
+		TagOrder:     DefaultTags,
+		EncodingTags: DefaultEncodingTags,
+		Provenance:   make(Provenance),
+	}
+
+	err := SetStructFields(&provenanceConfig{}, settings, map[string]any{
+		"PORT":     "9090",
+		"Mode":     "dev",
+		"name":     "edge",
+		"database": map[string]any{"dsn": "postgres://"},
+	})
+	requireNoError(t, err)
+
+	requireEqual(t, Provenance{
+		"Host":          {Match: MatchDefault, Tag: "default", Input: "0.0.0.0"},
+		"Port":          {Match: MatchEnv, Key: "PORT", Tag: "env", Input: "9090"},
+		"Mode":          {Match: MatchName, Key: "Mode", Input: "dev"},
+		"Name":          {Match: MatchTag, Key: "name", Tag: "json", Input: "edge"},
+		"Database.DSN":  {Match: MatchTag, Key: "database.dsn", Tag: "json", Input: "postgres://"},
+		"Database.Pool": {Match: MatchDefault, Tag: "default", Input: "4"},
+	}, settings.Provenance)
+}
+
+func Test_Struct_Provenance(t *testing.T) {
+	t.Run("off unless requested", func(t *testing.T) {
+		s := New(&provenanceConfig{})
+		requireNoError(t, s.Set(map[string]any{"name": "edge"}))
+		if s.Provenance() != nil {
+			t.Fatalf("expected no provenance, got %v", s.Provenance())
+		}
+	})
+
+	t.Run("printable report", func(t *testing.T) {
+		s := New(&provenanceConfig{}, WithProvenance())
+		requireNoError(t, s.Set(map[string]any{"PORT": 9090, "DATABASE_DSN": "postgres://"}))
+		requireEqual(t, "Database.DSN = postgres:// (env DATABASE_DSN)\n"+
+			"Database.Pool = 4 (default)\n"+
+			"Host = 0.0.0.0 (default)\n"+
+			"Port = 9090 (env PORT)\n", s.Provenance().String())
+	})
+
+	t.Run("loader sources are named", func(t *testing.T) {
+		t.Setenv("PORT", "7070")
+		s := New(&provenanceConfig{}, WithProvenance())
+		err := NewLoader(s,
+			MapSource("file", map[string]any{"database": map[string]any{"dsn": "postgres://"}, "port": 1}),
+			EnvSource(""),
+		).Load()
+		requireNoError(t, err)
+
+		requireEqual(t, Origin{Source: "env", Match: MatchEnv, Key: "PORT", Tag: "env", Input: "7070"}, s.Provenance()["Port"])
+		requireEqual(t, Origin{Source: "file", Match: MatchTag, Key: "database.dsn", Tag: "json", Input: "postgres://"}, s.Provenance()["Database.DSN"])
+		requireEqual(t, Origin{Match: MatchDefault, Tag: "default", Input: "0.0.0.0"}, s.Provenance()["Host"])
+	})
+}
@@ -1,9 +1,13 @@
 package structs
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 // DefaultRules is the built-in rule set, keyed by the name used in a `rules:`
@@ -12,6 +16,25 @@ import (
 var DefaultRules = map[string]RuleFunc{
 	"required": Required,
 	"oneof":    OneOf,
+	"min":      Min,
+	"max":      Max,
+	"len":      Len,
+	"between":  Between,
+	"email":    Email,
+	"url":      URL,
+	"uuid":     UUID,
+	"ip":       IP,
+	"ipv4":     IPv4,
+	"ipv6":     IPv6,
+	"cidr":     CIDR,
+	"hostname": Hostname,
+	"port":     Port,
+	"regex":    Regex,
+
+	"required_if":      RequiredIf,
+	"required_with":    RequiredWith,
+	"required_without": RequiredWithout,
+	"eqfield":          EqField,
 }
 
 // RuleFunc validates one field against one rule. It receives the lookup key
@@ -21,6 +44,17 @@ var DefaultRules = map[string]RuleFunc{
 // the returned error is for internal failures, not validation failures.
 type RuleFunc func(fieldName string, values map[string]any, defaultValue string, fieldValue reflect.Value, args []string) (map[string][]string, error)
 
+// presenceRules are the rules that check whether a field is set. When
+// validating current values a zero field reads as unset for them, as an absent
+// input does for Validate. true marks the rules whose referenced fields are
+// judged by presence too; required_if compares their values instead.
+var presenceRules = map[string]bool{
+	"required":         false,
+	"required_if":      false,
+	"required_with":    true,
+	"required_without": true,
+}
+
 // Required fails when a field has no non-empty input and no default and the
 // field's current value is zero. Whitespace-only string inputs count as empty.
 var Required RuleFunc = func(fieldName string, values map[string]any, defaultValue string, fieldValue reflect.Value, args []string) (map[string][]string, error) {
@@ -85,3 +119,218 @@ var OneOf RuleFunc = func(fieldName string, values map[string]any, defaultValue
 		fieldName: {"must be one of: " + strings.Join(args, ", ")},
 	}, nil
 }
+
+// Min fails when a field measures less than its arg, e.g. `rules:"min:1"`.
+// What is measured depends on the field's Kind: the number itself for ints,
+// uints and floats, the rune length for strings, and the element count for
+// slices, arrays and maps. The input is measured when there is one, else the
+// default, else the field's current value; with none of them the rule passes
+// (pair with `required` to force presence).
+// A time.Duration field is measured in nanoseconds, from a "30s" input too,
+// and its args may be durations: `rules:"min:1s|max:1h"`.
+var Min = measureRule("min", 1, func(size float64, bounds []float64) bool {
+	return size >= bounds[0]
+})
+
+// Max fails when a field measures more than its arg, e.g. `rules:"max:64"`.
+// See Min for what is measured.
+var Max = measureRule("max", 1, func(size float64, bounds []float64) bool {
+	return size <= bounds[0]
+})
+
+// Len fails when a field does not measure exactly its arg, e.g.
+// `rules:"len:2"` for a two-letter country code. See Min for what is measured.
+var Len = measureRule("len", 1, func(size float64, bounds []float64) bool {
+	return size == bounds[0]
+})
+
+// Between fails when a field measures outside its two inclusive args, e.g.
+// `rules:"between:1,65535"`. See Min for what is measured.
+var Between = measureRule("between", 2, func(size float64, bounds []float64) bool {
+	return size >= bounds[0] && size <= bounds[1]
+})
+
+// countRules are the measure rules that count the items of a slice, array or
+// map field. The validator splits a flat string input or default for them on
+// the field's sep tag, the way Set does, since a RuleFunc never sees the tags.
+var countRules = map[string]bool{
+	"min":     true,
+	"max":     true,
+	"len":     true,
+	"between": true,
+}
+
+// measureRule builds a RuleFunc that measures the field and checks the size
+// against the rule's numeric args.
+func measureRule(name string, argCount int, check func(size float64, bounds []float64) bool) RuleFunc {
+	return func(fieldName string, values map[string]any, defaultValue string, fieldValue reflect.Value, args []string) (map[string][]string, error) {
+		if len(args) != argCount {
+			return nil, fmt.Errorf("rule %s expects %d args, got %d", name, argCount, len(args))
+		}
+		bounds := make([]float64, len(args))
+		for i, arg := range args {
+			bound, err := strconv.ParseFloat(strings.TrimSpace(arg), 64)
+			if err != nil {
+				// a duration bound (1s) for a time.Duration field, in nanoseconds
+				duration, durationErr := time.ParseDuration(strings.TrimSpace(arg))
+				if durationErr != nil {
+					return nil, fmt.Errorf("rule %s arg %q is not a number: %w", name, arg, err)
+				}
+				bound = float64(duration)
+			}
+			bounds[i] = bound
+		}
+
+		size, unit, found, err := measureField(fieldName, values, defaultValue, fieldValue)
+		if errors.Is(err, errNotNumber) {
+			return map[string][]string{
+				fieldName: {"must be a number"},
+			}, nil
+		}
+		if errors.Is(err, errNotDuration) {
+			return map[string][]string{
+				fieldName: {"must be a duration"},
+			}, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", name, err)
+		}
+		if !found || check(size, bounds) {
+			//nolint:nilnil // nil, nil means no validation and internal errors
+			return nil, nil
+		}
+
+		return map[string][]string{
+			fieldName: {measureMessage(name, unit, args)},
+		}, nil
+	}
+}
+
+// measureUnit says what a field's size counts.
+type measureUnit int
+
+const (
+	measureNumber measureUnit = iota
+	measureCharacters
+	measureItems
+)
+
+// measureMessage phrases a failed measure rule for the unit measured, e.g.
+// "must be at least 3", "must be at most 8 characters long" or "must have
+// between 1 and 5 items".
+func measureMessage(name string, unit measureUnit, args []string) string {
+	bound := strings.Join(args, " and ")
+	switch name {
+	case "min":
+		bound = "at least " + bound
+	case "max":
+		bound = "at most " + bound
+	case "len":
+		bound = "exactly " + bound
+	case "between":
+		bound = "between " + bound
+	}
+
+	switch unit {
+	case measureCharacters:
+		return "must be " + bound + " characters long"
+	case measureItems:
+		if len(args) == 1 && args[0] == "1" {
+			return "must have " + bound + " item"
+		}
+		return "must have " + bound + " items"
+	default:
+		return "must be " + bound
+	}
+}
+
+// measureField measures the field's input, else its default, else its current
+// value, by the field's Kind. found is false when there is nothing to measure.
+func measureField(fieldName string, values map[string]any, defaultValue string, fieldValue reflect.Value) (size float64, unit measureUnit, found bool, err error) {
+	var value reflect.Value
+	if raw, ok := values[fieldName]; ok && raw != nil {
+		if s, isStr := raw.(string); !isStr || strings.TrimSpace(s) != "" {
+			value = reflect.ValueOf(raw)
+		}
+	}
+	if !value.IsValid() && defaultValue != "" {
+		value = reflect.ValueOf(defaultValue)
+	}
+	if !value.IsValid() && fieldValue.IsValid() && !fieldValue.IsZero() {
+		value = reflect.Indirect(fieldValue)
+	}
+	if !value.IsValid() {
+		return 0, measureNumber, false, nil
+	}
+
+	// the field's Kind decides what is measured, the value's when there is no field
+	kind := value.Kind()
+	isDuration := false
+	if fieldValue.IsValid() {
+		fieldType := fieldValue.Type()
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		kind = fieldType.Kind()
+		isDuration = fieldType == durationType
+	}
+
+	switch {
+	case isDuration && value.Kind() == reflect.String:
+		// "30s" measures as the field is set, a plain number as nanoseconds
+		duration, err := toDuration(value.String(), 0)
+		if err != nil {
+			return 0, measureNumber, true, fmt.Errorf("value %q: %w", value.String(), errNotDuration)
+		}
+		return float64(duration), measureNumber, true, nil
+	case isNumericKind(kind):
+		number, err := toNumber(value)
+		return number, measureNumber, true, err
+	case kind == reflect.String:
+		text := fmt.Sprintf("%v", value.Interface())
+		if value.Kind() == reflect.String {
+			text = value.String()
+		}
+		return float64(utf8.RuneCountInString(text)), measureCharacters, true, nil
+	case kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map:
+		switch value.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return float64(value.Len()), measureItems, true, nil
+		case reflect.String:
+			// a flat "a,b,c" input with no field sep at hand, split on the default
+			return float64(len(strings.Split(value.String(), defaultSeparator))), measureItems, true, nil
+		default:
+			return 1, measureItems, true, nil
+		}
+	default:
+		return 0, measureNumber, false, fmt.Errorf("cannot measure a %s field", kind)
+	}
+}
+
+// errNotNumber is returned by toNumber for a value that does not read as a
+// number, which measure rules report as a validation failure.
+var errNotNumber = errors.New("not a number")
+
+// errNotDuration is returned by measureField for a time.Duration field's input
+// that does not parse as a duration.
+var errNotDuration = errors.New("not a duration")
+
+// toNumber reads a numeric or numeric-string value as a float64.
+func toNumber(value reflect.Value) (float64, error) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(value.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	case reflect.String:
+		number, err := strconv.ParseFloat(strings.TrimSpace(value.String()), 64)
+		if err != nil {
+			return 0, fmt.Errorf("value %q: %w", value.String(), errNotNumber)
+		}
+		return number, nil
+	default:
+		return 0, fmt.Errorf("value of type %s: %w", value.Type(), errNotNumber)
+	}
+}
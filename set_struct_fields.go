@@ -1,10 +1,14 @@
 package structs
 
 import (
+	"encoding"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/toaweme/structs/utils"
 )
@@ -16,6 +20,32 @@ var ErrInputPointer = errors.New("structure should be a pointer")
 // but does not point to a struct.
 var ErrInputPointerStruct = errors.New("structure should be a pointer to a struct")
 
+// OverflowError is returned when a numeric input does not fit the sized int,
+// uint or float field it targets (e.g. 300 into an int8).
+type OverflowError struct {
+	// Field is the Go name of the field being set, empty for slice elements.
+	Field string
+	// Input is the raw input value that overflowed.
+	Input any
+	// Kind is the target field's kind (e.g. int8, float32).
+	Kind reflect.Kind
+	// Bits is the target type's size in bits.
+	Bits int
+}
+
+func newOverflowError(fieldName string, input any, fieldValue reflect.Value) *OverflowError {
+	return &OverflowError{
+		Field: fieldName,
+		Input: input,
+		Kind:  fieldValue.Kind(),
+		Bits:  fieldValue.Type().Bits(),
+	}
+}
+
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("value %v overflows field[%s] of %d-bit type %s", e.Input, e.Field, e.Bits, e.Kind)
+}
+
 // Settings controls how SetStructFields resolves inputs onto struct fields.
 type Settings struct {
 	// TagOrder is the tag priority used to match input keys to fields
@@ -33,6 +63,22 @@ type Settings struct {
 	// EncodingTags are the tags whose values use comma-separated options (see
 	// DefaultEncodingTags). Empty disables comma stripping.
 	EncodingTags []string
+	// Converters convert inputs for the field types they are keyed by, ahead of
+	// the built-in coercion. Use them for third-party types you can't add an
+	// Unmarshaler to. A struct type with a converter is set as a whole.
+	Converters map[reflect.Type]ConverterFunc
+	// DurationUnit is the unit a plain integer input is counted in for a
+	// time.Duration field ("30" with time.Second is 30s). Zero means
+	// nanoseconds, matching time.Duration itself. Strings such as "1m30s" are
+	// always parsed with time.ParseDuration.
+	DurationUnit time.Duration
+	// Strict makes SetStructFields fail with an *UnknownKeysError when inputs
+	// hold keys (nested map sections and struct list items included) that
+	// matched no field, after setting the fields that did match.
+	Strict bool
+	// Provenance, when not nil, records which input set each field, keyed by
+	// the field's Go path (e.g. "Database.DSN"). See Provenance.
+	Provenance Provenance
 }
 
 // SetStructFields sets the fields of a struct based on the inputs provided
@@ -47,6 +93,12 @@ func SetStructFields(structure any, settings Settings, inputs map[string]any) er
 		return err
 	}
 
+	if settings.Strict {
+		if unknown := unknownInputKeys(fields, settings, inputs); len(unknown) > 0 {
+			return &UnknownKeysError{Keys: unknown}
+		}
+	}
+
 	return nil
 }
 
@@ -54,11 +106,15 @@ func SetStructFields(structure any, settings Settings, inputs map[string]any) er
 // structs. It is the recursive worker behind SetStructFields.
 func SetFields(fields []Field, settings Settings, inputs map[string]any) error {
 	for _, field := range fields {
-		if field.Kind == reflect.Struct {
+		// nested structs (value or pointer) recurse. a struct that unmarshals
+		// itself has no nested Fields and is set like any other leaf, as is
+		// one with a registered converter.
+		if field.Fields != nil && !settings.hasConverter(field.Value.Type()) {
 			err := SetFields(field.Fields, settings, inputs)
 			if err != nil {
 				return err
 			}
+			attachPending(field)
 			continue
 		}
 
@@ -79,10 +135,11 @@ func SetField(field Field, settings Settings, inputs map[string]any) error {
 	if field.Default != "" {
 		// check if field has already a value set
 		if !field.Value.IsValid() || field.Value.IsZero() {
-			err := setField(field, field.Default)
+			err := setField(field, settings, field.Default)
 			if err != nil {
 				return fmt.Errorf("failed to set default value for field[%s]: %w", field.Name, err)
 			}
+			settings.record(field, Origin{Match: MatchDefault, Tag: defaultValueTag, Input: field.Default})
 		}
 	}
 
@@ -93,10 +150,11 @@ func SetField(field Field, settings Settings, inputs map[string]any) error {
 		if _, ok := field.Tags[envValueTag]; ok {
 			envKey := field.Tags[envValueTag]
 			if _, ok := inputs[envKey]; ok {
-				err := setField(field, inputs[envKey])
+				err := setField(field, settings, inputs[envKey])
 				if err != nil {
 					return err
 				}
+				settings.record(field, Origin{Match: MatchEnv, Key: envKey, Tag: envValueTag, Input: inputs[envKey]})
 
 				if !settings.AllowEnvOverride {
 					return nil
@@ -106,19 +164,21 @@ func SetField(field Field, settings Settings, inputs map[string]any) error {
 
 		// check exact field name match
 		if val, ok := inputs[field.Name]; ok {
-			err := setField(field, val)
+			err := setField(field, settings, val)
 			if err != nil {
 				return err
 			}
+			settings.record(field, Origin{Match: MatchName, Key: field.Name, Input: val})
 		}
 
 		// check tag matches
 		for _, tag := range settings.TagOrder {
 			if val, ok := inputs[field.Tags[tag]]; ok {
-				err := setField(field, val)
+				err := setField(field, settings, val)
 				if err != nil {
 					return err
 				}
+				settings.record(field, Origin{Match: MatchTag, Key: field.Tags[tag], Tag: tag, Input: val})
 
 				if !settings.AllowTagOverride {
 					return nil
@@ -126,8 +186,8 @@ func SetField(field Field, settings Settings, inputs map[string]any) error {
 			}
 		}
 
-		// check nested field matches
-		if field.Fields != nil {
+		// check nested field matches, a converter type is set as a whole
+		if field.Fields != nil && !settings.hasConverter(field.Value.Type()) {
 			err := SetFields(field.Fields, settings, inputs)
 			if err != nil {
 				return err
@@ -141,10 +201,11 @@ func SetField(field Field, settings Settings, inputs map[string]any) error {
 	if _, ok := fqn.Tags[envValueTag]; ok {
 		envKey := fqn.Tags[envValueTag]
 		if _, ok := inputs[envKey]; ok {
-			err := setField(field, inputs[envKey])
+			err := setField(field, settings, inputs[envKey])
 			if err != nil {
 				return err
 			}
+			settings.record(field, Origin{Match: MatchEnv, Key: envKey, Tag: envValueTag, Input: inputs[envKey]})
 
 			if !settings.AllowEnvOverride {
 				return nil
@@ -154,20 +215,22 @@ func SetField(field Field, settings Settings, inputs map[string]any) error {
 
 	// check fqn exact field name match
 	if val, ok := inputs[fqn.Name]; ok {
-		err := setField(field, val)
+		err := setField(field, settings, val)
 		if err != nil {
 			return err
 		}
+		settings.record(field, Origin{Match: MatchName, Key: fqn.Name, Input: val})
 	}
 
 	// check fqn tag matches
 	for _, tag := range settings.TagOrder {
 		fieldTag := fqn.Tags[tag]
 		if val, ok := inputs[fieldTag]; ok {
-			err := setField(field, val)
+			err := setField(field, settings, val)
 			if err != nil {
 				return err
 			}
+			settings.record(field, Origin{Match: MatchTag, Key: fieldTag, Tag: tag, Input: val})
 
 			if !settings.AllowTagOverride {
 				return nil
@@ -180,10 +243,11 @@ func SetField(field Field, settings Settings, inputs map[string]any) error {
 
 			found, value := findNestedValue(inputs, split)
 			if found {
-				err := setField(field, value)
+				err := setField(field, settings, value)
 				if err != nil {
 					return err
 				}
+				settings.record(field, Origin{Match: MatchTag, Key: fieldTag, Tag: tag, Input: value})
 
 				if !settings.AllowTagOverride {
 					return nil
@@ -192,8 +256,8 @@ func SetField(field Field, settings Settings, inputs map[string]any) error {
 		}
 	}
 
-	// check fqn nested field matches
-	if field.Fields != nil {
+	// check fqn nested field matches, a converter type is set as a whole
+	if field.Fields != nil && !settings.hasConverter(field.Value.Type()) {
 		err := SetFields(field.Fields, settings, inputs)
 		if err != nil {
 			return err
@@ -203,6 +267,19 @@ func SetField(field Field, settings Settings, inputs map[string]any) error {
 	return nil
 }
 
+// attachPending points a nil pointer-to-struct field at the struct its nested
+// fields were staged in, but only once something (an input or a default) left
+// that struct non-zero. A pointer nothing targeted stays nil.
+func attachPending(field Field) {
+	if !field.pending.IsValid() || !field.Value.IsNil() || !field.Value.CanSet() {
+		return
+	}
+	if field.pending.Elem().IsZero() {
+		return
+	}
+	field.Value.Set(field.pending)
+}
+
 func findNestedValue(inputs map[string]any, path []string) (bool, any) {
 	current := inputs
 
@@ -227,12 +304,19 @@ func findNestedValue(inputs map[string]any, path []string) (bool, any) {
 	return exists, value
 }
 
-func setField(field Field, input any) error {
-	if field.Kind == reflect.Slice {
-		input = splitSliceInput(field, input)
+func setField(field Field, settings Settings, input any) error {
+	if (field.Kind == reflect.Slice || field.Kind == reflect.Array) && field.Value.IsValid() && !hasUnmarshaler(field.Value.Type()) && !settings.hasConverter(field.Value.Type()) {
+		input = splitSliceInput(field, settings, input)
+	}
+	if field.Kind == reflect.Map && field.Value.IsValid() && !hasUnmarshaler(field.Value.Type()) && !settings.hasConverter(field.Value.Type()) {
+		pairs, err := splitMapInput(field, input)
+		if err != nil {
+			return fmt.Errorf("failed to set field[%s]: %w", field.Name, err)
+		}
+		input = pairs
 	}
 
-	err := setValue(field.Name, input, field.Kind, field.Value)
+	err := setValue(field.Name, input, field.Kind, field.Value, field.Tags[layoutTag], settings)
 	if err != nil {
 		return fmt.Errorf("failed to set field[%s]: %w", field.Name, err)
 	}
@@ -248,20 +332,20 @@ func setField(field Field, input any) error {
 type MultiValue []string
 
 // splitSliceInput splits a string or MultiValue into trimmed elements on the
-// field's sep tag (default ","). It applies only to a slice of scalars, so
-// already-structured inputs ([]string, []any from decoded config, struct-element
-// slices) pass through untouched. This lets an "a,b,c" input become
+// field's sep tag (default ","). It applies only to a slice or array of
+// scalars, so already-structured inputs ([]string, []any from decoded config,
+// struct-element slices) pass through untouched. This lets an "a,b,c" input become
 // ["a", "b", "c"] rather than a single-element ["a,b,c"].
 //
 // A field that sets sep:"" opts out of splitting: each value is kept verbatim and
 // may contain any character. This differs from an absent sep tag, which falls
 // back to ",". Use it for a repeated free-form flag whose occurrences should each
 // stay one whole element.
-func splitSliceInput(field Field, input any) any {
-	if !field.Value.IsValid() || field.Value.Kind() != reflect.Slice {
+func splitSliceInput(field Field, settings Settings, input any) any {
+	if !field.Value.IsValid() || (field.Value.Kind() != reflect.Slice && field.Value.Kind() != reflect.Array) {
 		return input
 	}
-	if field.Value.Type().Elem().Kind() == reflect.Struct {
+	if elemType := field.Value.Type().Elem(); isNestedStruct(elemType) && !settings.hasConverter(elemType) {
 		return input
 	}
 
@@ -316,7 +400,142 @@ func splitOnSep(s, sep string) []string {
 	return parts
 }
 
-func setValue(fieldName string, value any, fieldType reflect.Kind, fieldValue reflect.Value) error {
+// splitMapInput parses a flat "k1=v1,k2=v2" string (or a MultiValue of them,
+// one per repeated flag) into a map[string]any of string values, splitting
+// entries on the field's sep tag (default ",") and each entry at its first
+// "=". sep:"" keeps each string one whole entry. Other inputs pass through.
+func splitMapInput(field Field, input any) (any, error) {
+	sep, ok := field.Tags[separatorTag]
+	if !ok {
+		sep = defaultSeparator
+	}
+
+	var entries []string
+	switch v := input.(type) {
+	case string:
+		entries = []string{v}
+	case MultiValue:
+		entries = v
+	default:
+		return input, nil
+	}
+
+	pairs := make(map[string]any)
+	for _, entry := range entries {
+		parts := []string{strings.TrimSpace(entry)}
+		if sep != "" {
+			parts = splitOnSep(entry, sep)
+		}
+		for _, part := range parts {
+			if part == "" {
+				continue
+			}
+			key, value, found := strings.Cut(part, "=")
+			if !found {
+				return nil, fmt.Errorf("map entry %q is not key=value", part)
+			}
+			pairs[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	return pairs, nil
+}
+
+// Unmarshaler is implemented by field types that set themselves from a raw,
+// loosely-typed input value (a string from an env var, a map or number from a
+// decoded config file, ...). It takes precedence over encoding.TextUnmarshaler
+// and json.Unmarshaler.
+type Unmarshaler interface {
+	UnmarshalValue(value any) error
+}
+
+var (
+	unmarshalerType     = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// hasUnmarshaler reports whether a pointer to typ implements Unmarshaler,
+// encoding.TextUnmarshaler or json.Unmarshaler. Such types (net.IP,
+// netip.Addr, big.Int, ...) are set as a whole rather than by Kind, and are
+// never split or recursed into.
+func hasUnmarshaler(typ reflect.Type) bool {
+	ptr := reflect.PointerTo(typ)
+	return ptr.Implements(unmarshalerType) || ptr.Implements(textUnmarshalerType) || ptr.Implements(jsonUnmarshalerType)
+}
+
+// unmarshalValue feeds value to fieldValue's own unmarshaling hook, if it has
+// one: Unmarshaler gets the raw value, encoding.TextUnmarshaler gets string
+// and []byte inputs, and json.Unmarshaler gets anything else encoded as JSON
+// (or a string, verbatim when it is valid JSON and quoted otherwise). handled is
+// false when no hook applies and the Kind-based coercion should run instead.
+func unmarshalValue(fieldName string, value any, fieldValue reflect.Value) (bool, error) {
+	if value == nil || !fieldValue.CanAddr() || !hasUnmarshaler(fieldValue.Type()) {
+		return false, nil
+	}
+
+	// already the field's type (e.g. a net.IP handed over as-is)
+	if reflect.TypeOf(value).AssignableTo(fieldValue.Type()) {
+		fieldValue.Set(reflect.ValueOf(value))
+		return true, nil
+	}
+
+	target := fieldValue.Addr().Interface()
+	var err error
+	if u, ok := target.(Unmarshaler); ok {
+		err = u.UnmarshalValue(value)
+	} else if u, ok := target.(encoding.TextUnmarshaler); ok && isTextInput(value) {
+		err = u.UnmarshalText(textInput(value))
+	} else if u, ok := target.(json.Unmarshaler); ok {
+		var raw []byte
+		raw, err = jsonInput(value)
+		if err == nil {
+			err = u.UnmarshalJSON(raw)
+		}
+	} else {
+		return false, nil
+	}
+	if err != nil {
+		return true, fmt.Errorf("failed to unmarshal %v into field[%s] type %s: %w", value, fieldName, fieldValue.Type(), err)
+	}
+
+	return true, nil
+}
+
+func isTextInput(value any) bool {
+	switch value.(type) {
+	case string, []byte:
+		return true
+	default:
+		return false
+	}
+}
+
+func textInput(value any) []byte {
+	if b, ok := value.([]byte); ok {
+		return b
+	}
+	return []byte(value.(string)) //nolint:errcheck // guarded by isTextInput
+}
+
+func jsonInput(value any) ([]byte, error) {
+	if s, ok := value.(string); ok && json.Valid([]byte(s)) {
+		return []byte(s), nil
+	}
+	return json.Marshal(value)
+}
+
+func setValue(fieldName string, value any, fieldType reflect.Kind, fieldValue reflect.Value, layout string, settings Settings) error {
+	if handled, err := convertValue(fieldName, value, fieldValue, settings.Converters); handled {
+		return err
+	}
+	if handled, err := setTimeValue(fieldName, value, fieldValue, layout, settings); handled {
+		return err
+	}
+	if handled, err := unmarshalValue(fieldName, value, fieldValue); handled {
+		return err
+	}
+
 	switch fieldType {
 	case reflect.String:
 		s, err := utils.ToString(value)
@@ -329,15 +548,41 @@ func setValue(fieldName string, value any, fieldType reflect.Kind, fieldValue re
 		if err != nil {
 			return err
 		}
+		if fieldValue.OverflowFloat(float) {
+			return newOverflowError(fieldName, value, fieldValue)
+		}
 		fieldValue.SetFloat(float)
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		integer, err := utils.ToInt(value)
+		integer, err := utils.ToInt64(value)
+		if errors.Is(err, strconv.ErrRange) {
+			return newOverflowError(fieldName, value, fieldValue)
+		}
+		if err != nil {
+			return err
+		}
+		if fieldValue.OverflowInt(integer) {
+			return newOverflowError(fieldName, value, fieldValue)
+		}
+		fieldValue.SetInt(integer)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		integer, err := utils.ToUint64(value)
+		if errors.Is(err, strconv.ErrRange) {
+			return newOverflowError(fieldName, value, fieldValue)
+		}
 		if err != nil {
 			return err
 		}
-		fieldValue.Set(reflect.ValueOf(integer))
+		if fieldValue.OverflowUint(integer) {
+			return newOverflowError(fieldName, value, fieldValue)
+		}
+		fieldValue.SetUint(integer)
 	case reflect.Slice:
-		err := setSliceValue(value, fieldValue)
+		err := setSliceValue(value, fieldValue, layout, settings)
+		if err != nil {
+			return err
+		}
+	case reflect.Array:
+		err := setArrayValue(value, fieldValue, layout, settings)
 		if err != nil {
 			return err
 		}
@@ -352,8 +597,34 @@ func setValue(fieldName string, value any, fieldType reflect.Kind, fieldValue re
 		if value != nil {
 			fieldValue.Set(reflect.ValueOf(value))
 		}
+	case reflect.Pointer:
+		if value == nil {
+			return nil
+		}
+		rv := reflect.ValueOf(value)
+		if rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return nil
+		}
+		if rv.Type().AssignableTo(fieldValue.Type()) {
+			fieldValue.Set(rv)
+			return nil
+		}
+		// set through the existing pointee, or allocate one that is only
+		// attached once the value converted cleanly.
+		target := fieldValue
+		if fieldValue.IsNil() {
+			target = reflect.New(fieldValue.Type().Elem())
+		}
+		err := setValue(fieldName, value, target.Elem().Kind(), target.Elem(), layout, settings)
+		if err != nil {
+			return err
+		}
+		fieldValue.Set(target)
 	case reflect.Map:
-		fieldValue.Set(reflect.ValueOf(value))
+		err := setMapValue(value, fieldValue, layout, settings)
+		if err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("unsupported field[%s] type: %s", fieldName, fieldType)
 	}
@@ -361,7 +632,88 @@ func setValue(fieldName string, value any, fieldType reflect.Kind, fieldValue re
 	return nil
 }
 
-func setSliceValue(value any, fieldValue reflect.Value) error {
+// setMapValue sets a map field from a map input, converting each key and
+// value to the field's key and element types through setValue, so a decoded
+// map[string]any can fill a map[string]int, a map[string]time.Duration or a
+// map[string]Backend (whose values are populated like struct slice elements).
+// An input already of the field's type is set as-is.
+func setMapValue(value any, fieldValue reflect.Value, layout string, settings Settings) error {
+	fieldType := fieldValue.Type()
+	if value == nil {
+		fieldValue.Set(reflect.Zero(fieldType))
+		return nil
+	}
+
+	input := reflect.ValueOf(value)
+	if input.Type().AssignableTo(fieldType) {
+		fieldValue.Set(input)
+		return nil
+	}
+	if input.Kind() != reflect.Map {
+		return fmt.Errorf("cannot set %T to %s", value, fieldType)
+	}
+
+	keyType, elemType := fieldType.Key(), fieldType.Elem()
+	newMap := reflect.MakeMapWithSize(fieldType, input.Len())
+	iter := input.MapRange()
+	for iter.Next() {
+		rawKey := iter.Key().Interface()
+		key := reflect.New(keyType).Elem()
+		if err := setValue("", rawKey, keyType.Kind(), key, layout, settings); err != nil {
+			return fmt.Errorf("failed to convert key %v to %s: %w", rawKey, keyType, err)
+		}
+
+		rawElem := iter.Value().Interface()
+		label := fmt.Sprintf("%v", rawKey)
+		elem, err := mapElement(label, rawElem, elemType, layout, settings)
+		if err != nil {
+			return fmt.Errorf("failed to set key %s: %w", label, err)
+		}
+		newMap.SetMapIndex(key, elem)
+	}
+
+	fieldValue.Set(newMap)
+
+	return nil
+}
+
+// mapElement converts one map input value to elemType.
+func mapElement(label string, value any, elemType reflect.Type, layout string, settings Settings) (reflect.Value, error) {
+	if value == nil {
+		return reflect.Zero(elemType), nil
+	}
+	if input, ok := mapInput(value); ok && isNestedStruct(elemType) && !settings.hasConverter(elemType) {
+		return structElement(input, elemType, settings)
+	}
+
+	elem := reflect.New(elemType).Elem()
+	if err := setValue("", value, elemType.Kind(), elem, layout, settings); err != nil {
+		return reflect.Value{}, err
+	}
+	return elem, nil
+}
+
+// setArrayValue sets a fixed-size array field the way setSliceValue sets a
+// slice, element by element. Elements past the input's length are zeroed; an
+// input longer than the array is an error.
+func setArrayValue(value any, fieldValue reflect.Value, layout string, settings Settings) error {
+	elements := reflect.New(reflect.SliceOf(fieldValue.Type().Elem())).Elem()
+	err := setSliceValue(value, elements, layout, settings)
+	if err != nil {
+		return err
+	}
+	if elements.Len() > fieldValue.Len() {
+		return fmt.Errorf("%d elements do not fit in %s", elements.Len(), fieldValue.Type())
+	}
+
+	array := reflect.New(fieldValue.Type()).Elem()
+	reflect.Copy(array, elements)
+	fieldValue.Set(array)
+
+	return nil
+}
+
+func setSliceValue(value any, fieldValue reflect.Value, layout string, settings Settings) error {
 	fieldType := fieldValue.Type()
 	elemType := fieldType.Elem()
 
@@ -373,37 +725,16 @@ func setSliceValue(value any, fieldValue reflect.Value) error {
 	newSlice := reflect.MakeSlice(fieldType, len(slice), len(slice))
 
 	for i, val := range slice {
-		// a struct element fed a map: populate its fields from the map entries
-		if elemType.Kind() == reflect.Struct {
-			valReflect := reflect.ValueOf(val)
-			if valReflect.Kind() == reflect.Map {
-				newStruct := reflect.New(elemType).Elem()
-
-				for j := range elemType.NumField() {
-					field := elemType.Field(j)
-					structFieldValue := newStruct.Field(j)
-
-					if !structFieldValue.CanSet() {
-						continue
-					}
-
-					// match the map key to the field name, case-insensitively
-					for _, key := range valReflect.MapKeys() {
-						keyStr := fmt.Sprintf("%v", key.Interface())
-						if strings.EqualFold(keyStr, field.Name) {
-							mapValue := valReflect.MapIndex(key).Interface()
-							err := setValue(field.Name, mapValue, field.Type.Kind(), structFieldValue)
-							if err != nil {
-								return fmt.Errorf("failed to set field %s: %w", field.Name, err)
-							}
-							break
-						}
-					}
-				}
-
-				newSlice.Index(i).Set(newStruct)
-				continue
+		// a struct element fed a map (a decoded YAML/JSON list item) is
+		// populated like a top-level struct: by tags, defaults, env and nested
+		// fields, with the caller's settings.
+		if input, ok := mapInput(val); ok && isNestedStruct(elemType) && !settings.hasConverter(elemType) {
+			elem, err := structElement(input, elemType, settings)
+			if err != nil {
+				return fmt.Errorf("failed to set element %d: %w", i, err)
 			}
+			newSlice.Index(i).Set(elem)
+			continue
 		}
 
 		if val == nil {
@@ -411,12 +742,35 @@ func setSliceValue(value any, fieldValue reflect.Value) error {
 			continue
 		}
 
+		// an element type with a registered converter, or one that unmarshals
+		// itself (net.IP, slog.Level, a string-based enum), is fed each element
+		// like a scalar field, whatever its Kind.
+		if settings.hasConverter(elemType) || hasUnmarshaler(elemType) {
+			elem := reflect.New(elemType).Elem()
+			if err := setValue("", val, elemType.Kind(), elem, layout, settings); err != nil {
+				return fmt.Errorf("failed to set element %d: %w", i, err)
+			}
+			newSlice.Index(i).Set(elem)
+			continue
+		}
+
+		// a map element of another map type (e.g. map[string]any from decoded
+		// config into []map[string]int) is converted entry by entry.
+		if elemType.Kind() == reflect.Map && !reflect.TypeOf(val).AssignableTo(elemType) {
+			elem := reflect.New(elemType).Elem()
+			if err := setMapValue(val, elem, layout, settings); err != nil {
+				return fmt.Errorf("failed to set element %d: %w", i, err)
+			}
+			newSlice.Index(i).Set(elem)
+			continue
+		}
+
 		// a string element targeting a scalar slice (e.g. []int from "8080,9090")
 		// is coerced through the same converters used for top-level fields, since
 		// reflect cannot convert "8080" to int directly.
 		if s, ok := val.(string); ok && elemType.Kind() != reflect.String {
 			elem := reflect.New(elemType).Elem()
-			if err := setValue("", s, elemType.Kind(), elem); err != nil {
+			if err := setValue("", s, elemType.Kind(), elem, layout, settings); err != nil {
 				return fmt.Errorf("failed to convert %q to %s: %w", s, elemType, err)
 			}
 			newSlice.Index(i).Set(elem)
@@ -424,6 +778,18 @@ func setSliceValue(value any, fieldValue reflect.Value) error {
 		}
 
 		elemVal := reflect.ValueOf(val)
+		// numeric elements of another type (e.g. float64 from decoded JSON into
+		// []int8) also go through setValue, so an out-of-range element errors
+		// instead of silently wrapping in reflect's Convert.
+		if !elemVal.Type().AssignableTo(elemType) && isNumericKind(elemType.Kind()) && isNumericKind(elemVal.Kind()) {
+			elem := reflect.New(elemType).Elem()
+			if err := setValue("", val, elemType.Kind(), elem, layout, settings); err != nil {
+				return fmt.Errorf("failed to convert %v to %s: %w", val, elemType, err)
+			}
+			newSlice.Index(i).Set(elem)
+			continue
+		}
+
 		if !elemVal.Type().AssignableTo(elemType) {
 			if !elemVal.Type().ConvertibleTo(elemType) {
 				return fmt.Errorf("cannot assign or convert %T to %s", val, elemType)
@@ -438,3 +804,96 @@ func setSliceValue(value any, fieldValue reflect.Value) error {
 
 	return nil
 }
+
+// structElement builds a slice or map element of elemType, a struct or a
+// pointer to one, from input through GetStructFields and SetFields.
+// Provenance is not recorded for elements; in strict mode, their unknown keys
+// are reported with the rest by SetStructFields, under the element's path
+// ("servers[2].prot", "pools.eu.prot").
+func structElement(input map[string]any, elemType reflect.Type, settings Settings) (reflect.Value, error) {
+	structType := elemType
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+
+	element := reflect.New(structType)
+	fields, err := GetStructFields(element.Interface(), nil, settings.EncodingTags)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	elementSettings := settings
+	elementSettings.Provenance = nil
+	folded, _ := foldFieldNames(fields, input)
+	err = SetFields(fields, elementSettings, folded)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	if elemType.Kind() == reflect.Pointer {
+		return element, nil
+	}
+	return element.Elem(), nil
+}
+
+// foldFieldNames returns input with each key that matches a field's Go name
+// case-insensitively ("title" for Title) also available under the Go name, as
+// list items in decoded config are commonly keyed. Exact keys win. foldedKeys
+// holds the input keys that were folded, so strict mode counts them as known.
+func foldFieldNames(fields []Field, input map[string]any) (folded map[string]any, foldedKeys map[string]bool) {
+	folded = input
+	foldedKeys = make(map[string]bool)
+	copied := false
+	for _, field := range fields {
+		if _, ok := input[field.Name]; ok {
+			continue
+		}
+		for key, value := range input {
+			if !strings.EqualFold(key, field.Name) {
+				continue
+			}
+			if !copied {
+				folded = make(map[string]any, len(input)+1)
+				for k, v := range input {
+					folded[k] = v
+				}
+				copied = true
+			}
+			folded[field.Name] = value
+			foldedKeys[key] = true
+			break
+		}
+	}
+	return folded, foldedKeys
+}
+
+// mapInput returns value as a map[string]any when it is a map, whatever its
+// key and value types (e.g. map[any]any from a YAML decoder).
+func mapInput(value any) (map[string]any, bool) {
+	if m, ok := value.(map[string]any); ok {
+		return m, true
+	}
+
+	mapValue := reflect.ValueOf(value)
+	if mapValue.Kind() != reflect.Map {
+		return nil, false
+	}
+
+	m := make(map[string]any, mapValue.Len())
+	iter := mapValue.MapRange()
+	for iter.Next() {
+		m[fmt.Sprintf("%v", iter.Key().Interface())] = iter.Value().Interface()
+	}
+	return m, true
+}
+
+func isNumericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -1,7 +1,18 @@
 package structs
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/big"
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 )
 
 func Test_SetStructFields(t *testing.T) {
@@ -1046,3 +1057,573 @@ func Test_ParseTags_StripsOmitempty(t *testing.T) {
 	// non-stdlib tags without commas are unaffected
 	requireEqual(t, "required", tags["rules"])
 }
+
+func Test_SetField_Unsigned(t *testing.T) {
+	type target struct {
+		Port    uint16   `json:"port"`
+		Size    uint64   `json:"size" default:"1024"`
+		Workers uint     `json:"workers"`
+		IDs     []uint32 `json:"ids"`
+	}
+	settings := Settings{TagOrder: DefaultTags, EncodingTags: DefaultEncodingTags}
+
+	t.Run("strings, ints and defaults land in uint fields", func(t *testing.T) {
+		got := &target{}
+		err := SetStructFields(got, settings, map[string]any{
+			"port":    "8080",
+			"workers": 4,
+			"ids":     "1,2,3",
+		})
+		requireNoError(t, err)
+		requireEqual(t, &target{Port: 8080, Size: 1024, Workers: 4, IDs: []uint32{1, 2, 3}}, got)
+	})
+
+	t.Run("out of range value errors", func(t *testing.T) {
+		err := SetStructFields(&target{}, settings, map[string]any{"port": "65536"})
+		requireErrorContains(t, err, "overflows")
+	})
+
+	t.Run("negative value errors", func(t *testing.T) {
+		err := SetStructFields(&target{}, settings, map[string]any{"workers": -1})
+		requireErrorContains(t, err, "negative")
+	})
+}
+
+func Test_SetField_SizedNumbers(t *testing.T) {
+	type target struct {
+		Small  int8      `json:"small"`
+		Medium int32     `json:"medium"`
+		Large  int64     `json:"large" default:"-9000000000"`
+		Ratio  float32   `json:"ratio"`
+		Levels []int16   `json:"levels"`
+		Bytes  []int8    `json:"bytes"`
+		Gains  []float32 `json:"gains"`
+		Count  uint64    `json:"count"`
+	}
+	settings := Settings{TagOrder: DefaultTags, EncodingTags: DefaultEncodingTags}
+
+	t.Run("in range values land in sized fields", func(t *testing.T) {
+		got := &target{}
+		err := SetStructFields(got, settings, map[string]any{
+			"small":  "-128",
+			"medium": 70000,
+			"ratio":  "0.5",
+			"levels": "1,-2,3",
+			"bytes":  []any{float64(1), float64(2)},
+			"gains":  []any{1, 2.5},
+		})
+		requireNoError(t, err)
+		requireEqual(t, &target{
+			Small:  -128,
+			Medium: 70000,
+			Large:  -9000000000,
+			Ratio:  0.5,
+			Levels: []int16{1, -2, 3},
+			Bytes:  []int8{1, 2},
+			Gains:  []float32{1, 2.5},
+		}, got)
+	})
+
+	tests := []struct {
+		name   string
+		inputs map[string]any
+		field  string
+		bits   int
+	}{
+		{name: "int8 overflow", inputs: map[string]any{"small": "300"}, field: "Small", bits: 8},
+		{name: "int32 overflow", inputs: map[string]any{"medium": int64(1) << 40}, field: "Medium", bits: 32},
+		{name: "float32 overflow", inputs: map[string]any{"ratio": "1e300"}, field: "Ratio", bits: 32},
+		{name: "int8 slice element overflow", inputs: map[string]any{"bytes": []any{float64(1), float64(200)}}, field: "", bits: 8},
+		{name: "uint64 beyond int64", inputs: map[string]any{"large": uint64(math.MaxUint64)}, field: "Large", bits: 64},
+		{name: "float beyond int64", inputs: map[string]any{"small": 1e20}, field: "Small", bits: 8},
+		{name: "negative float beyond int64", inputs: map[string]any{"large": -1e20}, field: "Large", bits: 64},
+		{name: "string beyond int64", inputs: map[string]any{"large": "99999999999999999999"}, field: "Large", bits: 64},
+		{name: "string beyond uint64", inputs: map[string]any{"count": "99999999999999999999"}, field: "Count", bits: 64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SetStructFields(&target{}, settings, tt.inputs)
+			var overflow *OverflowError
+			if !errors.As(err, &overflow) {
+				t.Fatalf("expected *OverflowError, got %v", err)
+			}
+			requireEqual(t, tt.field, overflow.Field)
+			requireEqual(t, tt.bits, overflow.Bits)
+		})
+	}
+}
+
+type ptrDatabase struct {
+	URL  string `json:"url" env:"URL"`
+	Pool *int   `json:"pool"`
+}
+
+type ptrServer struct {
+	Name     *string      `json:"name"`
+	Port     *int         `json:"port" default:"8080"`
+	Debug    *bool        `json:"debug"`
+	Database *ptrDatabase `json:"database" env:"DATABASE"`
+}
+
+func Test_SetField_Pointers(t *testing.T) {
+	settings := Settings{TagOrder: DefaultTags, EncodingTags: DefaultEncodingTags}
+
+	t.Run("untargeted pointers stay nil, defaults allocate", func(t *testing.T) {
+		got := &ptrServer{}
+		err := SetStructFields(got, settings, map[string]any{})
+		requireNoError(t, err)
+		if got.Name != nil || got.Debug != nil || got.Database != nil {
+			t.Fatalf("expected untargeted pointers to stay nil, got %+v", got)
+		}
+		requireNotNil(t, got.Port)
+		requireEqual(t, 8080, *got.Port)
+	})
+
+	t.Run("scalar pointers are allocated and converted", func(t *testing.T) {
+		got := &ptrServer{}
+		err := SetStructFields(got, settings, map[string]any{"name": "edge", "port": "9090", "debug": "yes"})
+		requireNoError(t, err)
+		requireEqual(t, "edge", *got.Name)
+		requireEqual(t, 9090, *got.Port)
+		requireEqual(t, true, *got.Debug)
+	})
+
+	t.Run("existing pointee is set in place", func(t *testing.T) {
+		name := "old"
+		got := &ptrServer{Name: &name}
+		err := SetStructFields(got, settings, map[string]any{"name": "new"})
+		requireNoError(t, err)
+		requireEqual(t, "new", name)
+	})
+
+	inputs := []struct {
+		name   string
+		inputs map[string]any
+	}{
+		{name: "pointer struct by dotted key", inputs: map[string]any{"database.url": "mysql://", "database.pool": 4}},
+		{name: "pointer struct by nested map", inputs: map[string]any{"database": map[string]any{"url": "mysql://", "pool": "4"}}},
+		{name: "pointer struct by glued env key", inputs: map[string]any{"DATABASE_URL": "mysql://", "database.pool": 4}},
+	}
+	for _, tt := range inputs {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &ptrServer{}
+			err := SetStructFields(got, settings, tt.inputs)
+			requireNoError(t, err)
+			requireNotNil(t, got.Database)
+			requireEqual(t, "mysql://", got.Database.URL)
+			requireNotNil(t, got.Database.Pool)
+			requireEqual(t, 4, *got.Database.Pool)
+		})
+	}
+
+	t.Run("existing pointer struct is populated in place", func(t *testing.T) {
+		db := &ptrDatabase{URL: "old"}
+		got := &ptrServer{Database: db}
+		err := SetStructFields(got, settings, map[string]any{"database.url": "new"})
+		requireNoError(t, err)
+		requireEqual(t, "new", db.URL)
+	})
+
+	t.Run("typed nil pointer input leaves the field untouched", func(t *testing.T) {
+		name := "keep"
+		got := &ptrServer{Name: &name}
+		err := SetStructFields(got, settings, map[string]any{"name": (*string)(nil), "port": (*int)(nil)})
+		requireNoError(t, err)
+		requireEqual(t, &name, got.Name)
+		requireEqual(t, "keep", name)
+		requireNotNil(t, got.Port)
+		requireEqual(t, 8080, *got.Port)
+	})
+
+	t.Run("same-type pointer input is assigned as is", func(t *testing.T) {
+		name := "edge"
+		port := 9090
+		got := &ptrServer{}
+		err := SetStructFields(got, settings, map[string]any{"name": &name, "port": &port})
+		requireNoError(t, err)
+		if got.Name != &name || got.Port != &port {
+			t.Fatalf("expected input pointers to be assigned, got %+v", got)
+		}
+	})
+
+	t.Run("self-referential pointer expands one level", func(t *testing.T) {
+		type node struct {
+			Value string `json:"value"`
+			Next  *node  `json:"next"`
+		}
+		got := &node{}
+		err := SetStructFields(got, settings, map[string]any{"value": "a", "next.value": "b"})
+		requireNoError(t, err)
+		requireNotNil(t, got.Next)
+		requireEqual(t, "b", got.Next.Value)
+	})
+}
+
+// hookEnum unmarshals itself from any raw value through the package's own
+// Unmarshaler hook.
+type hookEnum int
+
+func (e *hookEnum) UnmarshalValue(value any) error {
+	switch fmt.Sprint(value) {
+	case "low":
+		*e = 1
+	case "high":
+		*e = 2
+	default:
+		return fmt.Errorf("unknown level %v", value)
+	}
+	return nil
+}
+
+// hookJSON only implements json.Unmarshaler, so it receives non-string inputs
+// encoded as JSON.
+type hookJSON struct {
+	Keys []string
+}
+
+func (h *hookJSON) UnmarshalJSON(data []byte) error {
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	for k := range m {
+		h.Keys = append(h.Keys, k)
+	}
+	sort.Strings(h.Keys)
+	return nil
+}
+
+// hookColor is a string-based enum that normalizes and checks itself.
+type hookColor string
+
+func (c *hookColor) UnmarshalText(text []byte) error {
+	switch color := strings.ToLower(string(text)); color {
+	case "red", "green":
+		*c = hookColor(color)
+		return nil
+	default:
+		return fmt.Errorf("unknown color %q", text)
+	}
+}
+
+func Test_SetField_Unmarshalers(t *testing.T) {
+	type target struct {
+		IP       net.IP       `json:"ip"`
+		Addr     netip.Addr   `json:"addr" default:"127.0.0.1"`
+		Level    slog.Level   `json:"level"`
+		Big      *big.Int     `json:"big"`
+		Enum     hookEnum     `json:"enum"`
+		JSON     hookJSON     `json:"json"`
+		Peers    []netip.Addr `json:"peers"`
+		Fallback net.IP       `json:"fallback"`
+	}
+	settings := Settings{TagOrder: DefaultTags, EncodingTags: DefaultEncodingTags}
+
+	got := &target{}
+	err := SetStructFields(got, settings, map[string]any{
+		"ip":       "10.0.0.1",
+		"level":    "warn",
+		"big":      "123456789012345678901234567890",
+		"enum":     "high",
+		"json":     map[string]any{"b": 1, "a": 2},
+		"peers":    "10.0.0.2, 10.0.0.3",
+		"fallback": net.ParseIP("::1"),
+	})
+	requireNoError(t, err)
+
+	requireEqual(t, "10.0.0.1", got.IP.String())
+	requireEqual(t, netip.MustParseAddr("127.0.0.1"), got.Addr)
+	requireEqual(t, slog.LevelWarn, got.Level)
+	requireNotNil(t, got.Big)
+	requireEqual(t, "123456789012345678901234567890", got.Big.String())
+	requireEqual(t, hookEnum(2), got.Enum)
+	requireEqual(t, []string{"a", "b"}, got.JSON.Keys)
+	requireEqual(t, []netip.Addr{netip.MustParseAddr("10.0.0.2"), netip.MustParseAddr("10.0.0.3")}, got.Peers)
+	requireEqual(t, "::1", got.Fallback.String())
+
+	t.Run("unmarshal failure names the field", func(t *testing.T) {
+		err := SetStructFields(&target{}, settings, map[string]any{"addr": "not-an-ip"})
+		requireErrorContains(t, err, "field[Addr]")
+	})
+
+	t.Run("custom hook failure surfaces", func(t *testing.T) {
+		err := SetStructFields(&target{}, settings, map[string]any{"enum": "medium"})
+		requireErrorContains(t, err, "unknown level")
+	})
+
+	t.Run("string-based enum slice elements unmarshal", func(t *testing.T) {
+		type palette struct {
+			One    hookColor   `json:"one"`
+			Colors []hookColor `json:"colors"`
+		}
+		got := &palette{}
+		err := SetStructFields(got, settings, map[string]any{"one": "RED", "colors": "RED,green"})
+		requireNoError(t, err)
+		requireEqual(t, hookColor("red"), got.One)
+		requireEqual(t, []hookColor{"red", "green"}, got.Colors)
+
+		err = SetStructFields(&palette{}, settings, map[string]any{"colors": []any{"red", "blue"}})
+		requireErrorContains(t, err, `unknown color "blue"`)
+	})
+}
+
+type sliceServerTLS struct {
+	Cert string `json:"cert" default:"server.pem"`
+}
+
+type sliceServer struct {
+	Host    string         `json:"host"`
+	Port    int            `json:"port" default:"8080"`
+	Tags    []string       `json:"tags"`
+	Timeout time.Duration  `json:"timeout"`
+	TLS     sliceServerTLS `json:"tls"`
+}
+
+type sliceServers struct {
+	Servers  []sliceServer  `json:"servers"`
+	Replicas []*sliceServer `json:"replicas"`
+}
+
+// struct slice elements are populated like the top-level struct: by tag,
+// with defaults, nested structs, nested slices and the caller's settings.
+func Test_SetField_StructSlices(t *testing.T) {
+	cfg := &sliceServers{}
+	s := New(cfg, WithTags("json"), WithDurationUnit(time.Second))
+
+	err := s.Set(map[string]any{
+		"servers": []any{
+			map[string]any{"host": "a", "port": "9000", "tags": "edge,beta", "timeout": 30, "tls": map[string]any{"cert": "a.pem"}},
+			map[any]any{"host": "b", "tls.cert": "b.pem"},
+		},
+		"replicas": []any{
+			map[string]any{"host": "r1"},
+			nil,
+		},
+	})
+	requireNoError(t, err)
+
+	requireEqual(t, []sliceServer{
+		{Host: "a", Port: 9000, Tags: []string{"edge", "beta"}, Timeout: 30 * time.Second, TLS: sliceServerTLS{Cert: "a.pem"}},
+		{Host: "b", Port: 8080, TLS: sliceServerTLS{Cert: "b.pem"}},
+	}, cfg.Servers)
+	requireLen(t, cfg.Replicas, 2)
+	requireEqual(t, &sliceServer{Host: "r1", Port: 8080, TLS: sliceServerTLS{Cert: "server.pem"}}, cfg.Replicas[0])
+	if cfg.Replicas[1] != nil {
+		t.Fatalf("nil replica should stay nil, got %+v", cfg.Replicas[1])
+	}
+}
+
+func Test_SetField_StructSlicesStrict(t *testing.T) {
+	err := New(&sliceServers{}, WithTags("json"), WithStrict()).Set(map[string]any{
+		"servers": []any{
+			map[string]any{"host": "a"},
+			map[string]any{"host": "b", "prot": 1},
+		},
+	})
+
+	var unknownKeysError *UnknownKeysError
+	if !errors.As(err, &unknownKeysError) {
+		t.Fatalf("expected *UnknownKeysError, got %v", err)
+	}
+	requireEqual(t, []UnknownKey{{Key: "servers[1].prot", Suggestion: "servers[1].port"}}, unknownKeysError.Keys)
+}
+
+func Test_SetField_StructSlicesStrictFolded(t *testing.T) {
+	type server struct {
+		Title string
+	}
+	type target struct {
+		Servers []server `json:"servers"`
+	}
+
+	got := &target{}
+	err := New(got, WithTags("json"), WithStrict()).Set(map[string]any{
+		"servers": []any{map[string]any{"title": "a"}},
+	})
+	requireNoError(t, err)
+	requireEqual(t, []server{{Title: "a"}}, got.Servers)
+
+	err = New(&target{}, WithTags("json"), WithStrict()).Set(map[string]any{
+		"servers": []any{map[string]any{"title": "a", "titel": "b"}},
+	})
+	var unknownKeysError *UnknownKeysError
+	if !errors.As(err, &unknownKeysError) {
+		t.Fatalf("expected *UnknownKeysError, got %v", err)
+	}
+	requireLen(t, unknownKeysError.Keys, 1)
+	requireEqual(t, "servers[0].titel", unknownKeysError.Keys[0].Key)
+}
+
+func Test_SetField_StructElementsStrictPaths(t *testing.T) {
+	type host struct {
+		Host string `json:"host"`
+	}
+	type site struct {
+		Name    string          `json:"name"`
+		Backup  []host          `json:"backup"`
+		Mirrors map[string]host `json:"mirrors"`
+	}
+	type target struct {
+		Port   int    `json:"port"`
+		Backup []host `json:"backup"`
+		Sites  []site `json:"sites"`
+	}
+
+	got := &target{}
+	err := New(got, WithTags("json"), WithStrict()).Set(map[string]any{
+		"port":   8080,
+		"backup": []any{map[string]any{"hots": "x"}},
+		"sites": []any{map[string]any{
+			"name":    "eu",
+			"backup":  []any{map[string]any{"host": "a"}, map[string]any{"hots": "b"}},
+			"mirrors": map[string]any{"west": map[string]any{"hots": "c"}},
+		}},
+		"prot": 80,
+	})
+
+	var unknownKeysError *UnknownKeysError
+	if !errors.As(err, &unknownKeysError) {
+		t.Fatalf("expected *UnknownKeysError, got %v", err)
+	}
+	requireEqual(t, []UnknownKey{
+		{Key: "backup[0].hots", Suggestion: "backup[0].host"},
+		{Key: "prot", Suggestion: "port"},
+		{Key: "sites[0].backup[1].hots", Suggestion: "sites[0].backup[1].host"},
+		{Key: "sites[0].mirrors.west.hots", Suggestion: "sites[0].mirrors.west.host"},
+	}, unknownKeysError.Keys)
+
+	// like top-level keys, every field is set before the unknown keys are reported
+	requireEqual(t, 8080, got.Port)
+	requireLen(t, got.Sites, 1)
+	requireEqual(t, "eu", got.Sites[0].Name)
+	requireEqual(t, []host{{Host: "a"}, {}}, got.Sites[0].Backup)
+}
+
+type mapBackend struct {
+	Host   string `json:"host"`
+	Weight int    `json:"weight" default:"1"`
+}
+
+type mapConfig struct {
+	Limits   map[string]int                `json:"limits"`
+	Timeouts map[string]time.Duration      `json:"timeouts"`
+	Ports    map[int]string                `json:"ports"`
+	Backends map[string]mapBackend         `json:"backends"`
+	Pools    map[string]*mapBackend        `json:"pools"`
+	Nested   map[string]map[string]float64 `json:"nested"`
+	Labels   map[string]string             `json:"labels"`
+	Env      map[string]string             `json:"env" sep:";"`
+	Raw      map[string]any                `json:"raw"`
+}
+
+// map fields are converted key by key and value by value through the same
+// coercion as scalars and struct elements.
+func Test_SetField_Maps(t *testing.T) {
+	raw := map[string]any{"a": 1}
+	cfg := &mapConfig{}
+	err := New(cfg, WithTags("json")).Set(map[string]any{
+		"limits":   map[string]any{"cpu": 2, "memory": "512"},
+		"timeouts": map[string]any{"read": "5s", "write": float64(1000000000)},
+		"ports":    map[any]any{"80": "http", 443: "https"},
+		"backends": map[string]any{"eu": map[string]any{"host": "eu.example.com", "weight": "3"}},
+		"pools":    map[string]any{"main": map[string]any{"host": "db"}, "spare": nil},
+		"nested":   map[string]any{"eu": map[string]any{"ratio": "0.5"}},
+		"labels":   "env=prod, tier = db,empty=",
+		"env":      "PATH=/bin:/usr/bin;LANG=C,UTF-8",
+		"raw":      raw,
+	})
+	requireNoError(t, err)
+
+	requireEqual(t, map[string]int{"cpu": 2, "memory": 512}, cfg.Limits)
+	requireEqual(t, map[string]time.Duration{"read": 5 * time.Second, "write": time.Second}, cfg.Timeouts)
+	requireEqual(t, map[int]string{80: "http", 443: "https"}, cfg.Ports)
+	requireEqual(t, map[string]mapBackend{"eu": {Host: "eu.example.com", Weight: 3}}, cfg.Backends)
+	requireEqual(t, map[string]*mapBackend{"main": {Host: "db", Weight: 1}, "spare": nil}, cfg.Pools)
+	requireEqual(t, map[string]map[string]float64{"eu": {"ratio": 0.5}}, cfg.Nested)
+	requireEqual(t, map[string]string{"env": "prod", "tier": "db", "empty": ""}, cfg.Labels)
+	requireEqual(t, map[string]string{"PATH": "/bin:/usr/bin", "LANG": "C,UTF-8"}, cfg.Env)
+	requireEqual(t, raw, cfg.Raw)
+}
+
+func Test_SetField_MapErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		inputs map[string]any
+		want   string
+	}{
+		{name: "malformed entry", inputs: map[string]any{"labels": "env=prod,tier"}, want: `map entry "tier" is not key=value`},
+		{name: "unconvertible value", inputs: map[string]any{"limits": map[string]any{"cpu": "two"}}, want: "failed to set key cpu"},
+		{name: "unconvertible key", inputs: map[string]any{"ports": map[string]any{"http": "80"}}, want: "failed to convert key http"},
+		{name: "not a map", inputs: map[string]any{"limits": 3}, want: "cannot set int to map[string]int"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := New(&mapConfig{}, WithTags("json")).Set(tt.inputs)
+			requireErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func Test_SetField_MapFlags(t *testing.T) {
+	cfg := &struct {
+		Labels map[string]int `arg:"label"`
+	}{}
+	_, err := New(cfg).SetFromArgs([]string{"--label", "a=1,b=2", "--label", "c=3"})
+	requireNoError(t, err)
+	requireEqual(t, map[string]int{"a": 1, "b": 2, "c": 3}, cfg.Labels)
+}
+
+type arrayConfig struct {
+	Mask    [4]byte        `json:"mask" sep:"."`
+	Weights [3]float64     `json:"weights"`
+	Names   [2]string      `json:"names"`
+	Servers [2]sliceServer `json:"servers"`
+}
+
+// array fields are set like slices: split on sep, element by element, with
+// the elements past the input zeroed.
+func Test_SetField_Arrays(t *testing.T) {
+	cfg := &arrayConfig{Weights: [3]float64{9, 9, 9}}
+	err := New(cfg, WithTags("json")).Set(map[string]any{
+		"mask":    "255.255.255.0",
+		"weights": []any{0.5, "1.5"},
+		"names":   [2]string{"a", "b"},
+		"servers": []any{map[string]any{"host": "a"}},
+	})
+	requireNoError(t, err)
+
+	requireEqual(t, [4]byte{255, 255, 255, 0}, cfg.Mask)
+	requireEqual(t, [3]float64{0.5, 1.5, 0}, cfg.Weights)
+	requireEqual(t, [2]string{"a", "b"}, cfg.Names)
+	requireEqual(t, [2]sliceServer{{Host: "a", Port: 8080, TLS: sliceServerTLS{Cert: "server.pem"}}}, cfg.Servers)
+}
+
+func Test_SetField_ArrayErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		inputs map[string]any
+		want   string
+	}{
+		{name: "too many elements", inputs: map[string]any{"weights": "1,2,3,4"}, want: "failed to set field[Weights]: 4 elements do not fit in [3]float64"},
+		{name: "element overflow", inputs: map[string]any{"mask": "255.255.256.0"}, want: "failed to set field[Mask]"},
+		{name: "unconvertible element", inputs: map[string]any{"weights": "1,x"}, want: `failed to convert "x" to float64`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := New(&arrayConfig{}, WithTags("json")).Set(tt.inputs)
+			requireErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func Test_SetField_ArrayFlags(t *testing.T) {
+	cfg := &struct {
+		Point [2]int `arg:"point"`
+	}{}
+	_, err := New(cfg).SetFromArgs([]string{"--point", "3", "--point", "4"})
+	requireNoError(t, err)
+	requireEqual(t, [2]int{3, 4}, cfg.Point)
+}
@@ -0,0 +1,248 @@
+package structs
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownKey is one input key that matched no field.
+type UnknownKey struct {
+	// Key is the input key, dotted for a key inside a nested map section
+	// (e.g. "database.dns").
+	Key string
+	// Suggestion is the closest known key, when one is near enough to be a
+	// likely typo; empty otherwise.
+	Suggestion string
+}
+
+// UnknownKeysError is returned in strict mode (Settings.Strict, WithStrict)
+// when inputs hold keys that matched no field by name, tag, FQN or env key.
+type UnknownKeysError struct {
+	// Keys are the unknown keys, sorted.
+	Keys []UnknownKey
+}
+
+func (e *UnknownKeysError) Error() string {
+	parts := make([]string, 0, len(e.Keys))
+	for _, key := range e.Keys {
+		if key.Suggestion != "" {
+			parts = append(parts, key.Key+" (did you mean "+key.Suggestion+"?)")
+			continue
+		}
+		parts = append(parts, key.Key)
+	}
+	return "unknown input keys: " + strings.Join(parts, ", ")
+}
+
+// unknownInputKeys returns the keys in inputs, descending into nested
+// map[string]any sections and into the map items of struct-element slices and
+// maps, that SetField could not match to any of fields.
+func unknownInputKeys(fields []Field, settings Settings, inputs map[string]any) []UnknownKey {
+	keys := make([]UnknownKey, 0)
+	findUnknownInputKeys(fields, settings, inputs, "", nil, &keys)
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Key < keys[j].Key })
+	return keys
+}
+
+// findUnknownInputKeys adds the unknown keys of inputs, matched against
+// fields, to keys under prefix (the element path, "backup[0]." for a slice
+// item). folded are keys that matched a field's Go name case-insensitively.
+func findUnknownInputKeys(fields []Field, settings Settings, inputs map[string]any, prefix string, folded map[string]bool, keys *[]UnknownKey) {
+	known := make(map[string]bool)
+	sections := make(map[string]bool)
+	elements := make(map[string]reflect.Type)
+	collectKnownKeys(fields, settings, known, sections, elements)
+
+	unknown := make([]string, 0)
+	findUnknownKeys(inputs, "", known, sections, &unknown)
+	for _, key := range unknown {
+		if folded[key] {
+			continue
+		}
+		suggestion := suggestKey(key, known)
+		if suggestion != "" {
+			suggestion = prefix + suggestion
+		}
+		*keys = append(*keys, UnknownKey{Key: prefix + key, Suggestion: suggestion})
+	}
+
+	for key, elemType := range elements {
+		if value, ok := inputs[key]; ok {
+			findUnknownElementKeys(value, prefix+key, elemType, settings, keys)
+		} else if ok, value := findNestedValue(inputs, strings.Split(key, ".")); ok {
+			findUnknownElementKeys(value, prefix+key, elemType, settings, keys)
+		}
+	}
+}
+
+// findUnknownElementKeys checks each map item of a struct-element slice or map
+// input against the element struct's fields, reporting under "path[i]." or
+// "path.key.". Items that are not maps are left for Set to report.
+func findUnknownElementKeys(value any, path string, elemType reflect.Type, settings Settings, keys *[]UnknownKey) {
+	structType := elemType
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	fields, err := GetStructFields(reflect.New(structType).Interface(), nil, settings.EncodingTags)
+	if err != nil {
+		return
+	}
+
+	check := func(label string, item any) {
+		input, ok := mapInput(item)
+		if !ok {
+			return
+		}
+		_, folded := foldFieldNames(fields, input)
+		findUnknownInputKeys(fields, settings, input, label+".", folded, keys)
+	}
+
+	if items, ok := mapInput(value); ok {
+		for key, item := range items {
+			check(path+"."+key, item)
+		}
+		return
+	}
+	items := reflect.ValueOf(value)
+	if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+		return
+	}
+	for i := 0; i < items.Len(); i++ {
+		check(fmt.Sprintf("%s[%d]", path, i), items.Index(i).Interface())
+	}
+}
+
+// collectKnownKeys adds every key SetField matches fields by to known: the env
+// key, the Go name and each TagOrder tag, all through the FQN for nested
+// fields. The dotted tag keys' parents are added to sections, the nested map
+// sections findNestedValue descends through. The keys of a slice, array or
+// map field of structs are added to elements, with the element type.
+func collectKnownKeys(fields []Field, settings Settings, known, sections map[string]bool, elements map[string]reflect.Type) {
+	for _, field := range fields {
+		if field.Fields != nil && !settings.hasConverter(field.Value.Type()) {
+			collectKnownKeys(field.Fields, settings, known, sections, elements)
+			continue
+		}
+
+		named := field
+		if field.FQN != nil {
+			named = *field.FQN
+		}
+		elemType, isElements := structElementType(field, settings)
+		keys := make([]string, 0, len(settings.TagOrder)+2)
+		if envKey := named.Tags[envValueTag]; envKey != "" {
+			keys = append(keys, envKey)
+		}
+		keys = append(keys, named.Name)
+		for _, tag := range settings.TagOrder {
+			key := named.Tags[tag]
+			if key == "" {
+				continue
+			}
+			keys = append(keys, key)
+			for i := strings.LastIndexByte(key, '.'); i > 0; i = strings.LastIndexByte(key[:i], '.') {
+				sections[key[:i]] = true
+			}
+		}
+		for _, key := range keys {
+			known[key] = true
+			if isElements {
+				elements[key] = elemType
+			}
+		}
+	}
+}
+
+// structElementType returns the element type of a slice, array or map field
+// whose elements are populated as structs (see structElement).
+func structElementType(field Field, settings Settings) (reflect.Type, bool) {
+	if !field.Value.IsValid() {
+		return nil, false
+	}
+	typ := field.Value.Type()
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+	default:
+		return nil, false
+	}
+	if hasUnmarshaler(typ) || settings.hasConverter(typ) {
+		return nil, false
+	}
+	elemType := typ.Elem()
+	if !isNestedStruct(elemType) || settings.hasConverter(elemType) {
+		return nil, false
+	}
+	return elemType, true
+}
+
+func findUnknownKeys(inputs map[string]any, prefix string, known, sections map[string]bool, unknown *[]string) {
+	for key, value := range inputs {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if known[path] {
+			continue
+		}
+		if nested, ok := value.(map[string]any); ok && sections[path] {
+			findUnknownKeys(nested, path, known, sections, unknown)
+			continue
+		}
+		*unknown = append(*unknown, path)
+	}
+}
+
+// suggestKey returns the known key closest to key by case-insensitive edit
+// distance, if it is within a third of key's length (and at least 2 edits),
+// else "". Ties go to the closest exact-case match, then alphabetically.
+func suggestKey(key string, known map[string]bool) string {
+	maxDistance := len(key) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+
+	best, bestDistance, bestCaseDistance := "", maxDistance+1, 0
+	for candidate := range known {
+		distance := editDistance(strings.ToLower(key), strings.ToLower(candidate))
+		if distance > bestDistance {
+			continue
+		}
+		caseDistance := editDistance(key, candidate)
+		if distance < bestDistance || caseDistance < bestCaseDistance || (caseDistance == bestCaseDistance && candidate < best) {
+			best, bestDistance, bestCaseDistance = candidate, distance, caseDistance
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
@@ -0,0 +1,79 @@
+package structs
+
+import (
+	"errors"
+	"testing"
+)
+
+type strictDatabase struct {
+	DSN  string `json:"dsn" env:"DSN"`
+	Pool int    `json:"pool"`
+}
+
+type strictConfig struct {
+	Host     string            `json:"host"`
+	Port     int               `json:"port" env:"PORT"`
+	Labels   map[string]string `json:"labels"`
+	Database strictDatabase    `json:"database" env:"DATABASE"`
+}
+
+func Test_SetStructFields_Strict(t *testing.T) {
+	settings := Settings{TagOrder: DefaultTags, EncodingTags: DefaultEncodingTags, Strict: true}
+
+	t.Run("every known form passes", func(t *testing.T) {
+		got := &strictConfig{}
+		err := SetStructFields(got, settings, map[string]any{
+			"host":         "localhost",
+			"PORT":         "80",
+			"labels":       map[string]string{"team": "core"},
+			"DATABASE_DSN": "postgres://",
+			"database":     map[string]any{"pool": 2},
+			"Database.DSN": "postgres://",
+		})
+		requireNoError(t, err)
+		requireEqual(t, 2, got.Database.Pool)
+	})
+
+	t.Run("unknown keys are reported with suggestions", func(t *testing.T) {
+		got := &strictConfig{}
+		err := SetStructFields(got, settings, map[string]any{
+			"host":        "localhost",
+			"databse.dsn": "postgres://",
+			"database":    map[string]any{"pol": 2, "dsn": "postgres://"},
+			"prot":        80,
+			"zzz":         true,
+		})
+		var unknown *UnknownKeysError
+		if !errors.As(err, &unknown) {
+			t.Fatalf("expected *UnknownKeysError, got %v", err)
+		}
+		requireEqual(t, []UnknownKey{
+			{Key: "database.pol", Suggestion: "database.pool"},
+			{Key: "databse.dsn", Suggestion: "database.dsn"},
+			{Key: "prot", Suggestion: "port"},
+			{Key: "zzz"},
+		}, unknown.Keys)
+		requireErrorContains(t, err, "databse.dsn (did you mean database.dsn?)")
+		// the keys that did match are still set
+		requireEqual(t, "localhost", got.Host)
+		requireEqual(t, "postgres://", got.Database.DSN)
+	})
+
+	t.Run("off by default", func(t *testing.T) {
+		lax := settings
+		lax.Strict = false
+		err := SetStructFields(&strictConfig{}, lax, map[string]any{"zzz": true})
+		requireNoError(t, err)
+	})
+}
+
+func Test_Struct_WithStrict(t *testing.T) {
+	err := New(&strictConfig{}, WithStrict()).Set(map[string]any{"hots": "x"})
+	requireErrorContains(t, err, "hots (did you mean host?)")
+
+	err = NewLoader(New(&strictConfig{}, WithStrict()),
+		MapSource("file", map[string]any{"database": map[string]any{"dns": "x"}}),
+	).Load()
+	requireErrorContains(t, err, "source file")
+	requireErrorContains(t, err, "database.dns (did you mean database.dsn?)")
+}
@@ -2,6 +2,8 @@ package structs
 
 import (
 	"fmt"
+	"reflect"
+	"time"
 )
 
 // Struct holds the structure to be validated and the rules to validate it with
@@ -11,9 +13,21 @@ type Struct struct {
 	validationTag string
 	tags          []string
 	encodingTags  []string
+	converters    map[reflect.Type]ConverterFunc
+	durationUnit  time.Duration
+	// trackProvenance enables recording provenance on Set, see WithProvenance.
+	trackProvenance bool
+	provenance      Provenance
+	strict          bool
+	// messages and locale select the validation message templates, see
+	// WithMessages and WithLocale.
+	messages MessageCatalog
+	locale   string
 }
 
-// Option configures a Struct. See WithTags, WithEncodingTags, WithRules, WithValidationTag.
+// Option configures a Struct. See WithTags, WithEncodingTags, WithRules,
+// WithValidationTag, WithConverter, WithDurationUnit, WithProvenance,
+// WithStrict, WithMessages, WithLocale.
 type Option func(*Struct)
 
 // WithTags sets the tag priority order used for input lookup and validation.
@@ -41,6 +55,66 @@ func WithValidationTag(tag string) Option {
 	return func(s *Struct) { s.validationTag = tag }
 }
 
+// WithConverter registers a ConverterFunc for fields of type typ (and
+// pointers to it), consulted before the built-in coercion when setting inputs
+// and default tag values. Call it once per type; a later call for the same
+// type replaces the earlier one.
+func WithConverter(typ reflect.Type, convert ConverterFunc) Option {
+	return func(s *Struct) {
+		if s.converters == nil {
+			s.converters = make(map[reflect.Type]ConverterFunc)
+		}
+		s.converters[typ] = convert
+	}
+}
+
+// WithDurationUnit sets the unit a plain integer counts in when set on a
+// time.Duration field, e.g. time.Second so that "30" means 30s. Defaults to
+// nanoseconds.
+func WithDurationUnit(unit time.Duration) Option {
+	return func(s *Struct) { s.durationUnit = unit }
+}
+
+// WithProvenance makes Set record which input (and, through a Loader, which
+// source) set each field, readable afterwards from Provenance.
+func WithProvenance() Option {
+	return func(s *Struct) { s.trackProvenance = true }
+}
+
+// WithStrict makes Set (and a Loader) fail with an *UnknownKeysError listing
+// every input key that matched no field, with "did you mean" suggestions, so a
+// typo such as "databse.dsn" is caught instead of silently ignored.
+func WithStrict() Option {
+	return func(s *Struct) { s.strict = true }
+}
+
+// WithMessages adds message templates to the catalog Validate and Check
+// report failures with, locale by locale and rule by rule, on top of
+// DefaultMessages. Select the locale with WithLocale.
+func WithMessages(catalog MessageCatalog) Option {
+	return func(s *Struct) {
+		merged := make(MessageCatalog)
+		for _, c := range []MessageCatalog{s.messages, catalog} {
+			for locale, messages := range c {
+				if merged[locale] == nil {
+					merged[locale] = make(Messages)
+				}
+				for rule, template := range messages {
+					merged[locale][rule] = template
+				}
+			}
+		}
+		s.messages = merged
+	}
+}
+
+// WithLocale selects the catalog locale (e.g. "de" or "pt-BR") failures are
+// reported in. Rules the locale has no message for fall back to its base
+// language, then DefaultLocale. Defaults to DefaultLocale.
+func WithLocale(locale string) Option {
+	return func(s *Struct) { s.locale = locale }
+}
+
 // DefaultTags is the default tag priority order for input lookup and validation.
 var DefaultTags = []string{"json", "yaml"}
 
@@ -65,6 +139,8 @@ func New(structure any, opts ...Option) *Struct {
 		tags:          DefaultTags,
 		ruleFuncs:     DefaultRules,
 		encodingTags:  DefaultEncodingTags,
+		messages:      DefaultMessages,
+		locale:        DefaultLocale,
 	}
 	for _, opt := range opts {
 		opt(s)
@@ -74,33 +150,136 @@ func New(structure any, opts ...Option) *Struct {
 
 // Validate runs the configured rules over inputs and returns the validation
 // errors as a map of field name (resolved by tag priority, or the validation
-// tag when present) to messages. An empty map means everything passed.
+// tag when present) to messages. An empty map means everything passed. For
+// output in a stable order, use Check and ValidationErrors.Ordered.
 func (m *Struct) Validate(inputs map[string]any) (map[string][]string, error) {
+	validationErrors, err := m.validate(m.validator(), inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	return validationErrors.Map(), nil
+}
+
+// Check is Validate returning the failures as an error: nil when everything
+// passed, otherwise ValidationErrors (reach it with errors.As) listing each
+// failed rule with its field, args and offending value.
+func (m *Struct) Check(inputs map[string]any) error {
+	validationErrors, err := m.validate(m.validator(), inputs)
+	if err != nil {
+		return err
+	}
+	if len(validationErrors) > 0 {
+		return validationErrors
+	}
+
+	return nil
+}
+
+// ValidateValue runs the configured rules over the struct's current field
+// values instead of an inputs map, typically after Set, so defaults, env
+// fallbacks, merged sources and values assigned in code are all judged the
+// same way. A zero int is measured like Validate measures an input of 0, while
+// required and the other presence rules read a zero field as unset, like an
+// absent input. The fields of a nil optional *Struct are skipped, and
+// defaults are not consulted.
+func (m *Struct) ValidateValue() (map[string][]string, error) {
+	v := m.validator()
+	v.current = true
+	validationErrors, err := m.validate(v, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return validationErrors.Map(), nil
+}
+
+// CheckValue is ValidateValue returning the failures as ValidationErrors, nil
+// when everything passed. See Check.
+func (m *Struct) CheckValue() error {
+	v := m.validator()
+	v.current = true
+	validationErrors, err := m.validate(v, nil)
+	if err != nil {
+		return err
+	}
+	if len(validationErrors) > 0 {
+		return validationErrors
+	}
+
+	return nil
+}
+
+func (m *Struct) validate(v validator, inputs map[string]any) (ValidationErrors, error) {
 	structFields, err := GetStructFields(m.structure, nil, m.encodingTags)
 	if err != nil {
 		return nil, fmt.Errorf("error getting struct fields for validation: %w", err)
 	}
 
-	errors, err := ValidateStructFields(m.ruleFuncs, structFields, inputs, m.validationTag, m.tags...)
+	validationErrors, err := v.validate(structFields, inputs)
 	if err != nil {
 		return nil, fmt.Errorf("error validating struct with inputs: %w", err)
 	}
 
-	return errors, nil
+	return validationErrors, nil
 }
 
-// Set populates the bound struct from inputs, resolving keys by tag priority
-// and applying `default:` tag values to fields left zero.
-func (m *Struct) Set(inputs map[string]any) error {
-	err := SetStructFields(m.structure, Settings{
+// validator builds the validator Validate, Check and their Value forms share from the Struct's
+// options.
+func (m *Struct) validator() validator {
+	return validator{
+		ruleFuncs:     m.ruleFuncs,
+		validationTag: m.validationTag,
+		tagPriority:   m.tags,
+		messages:      m.messages.messages(m.locale),
+		encodingTags:  m.encodingTags,
+	}
+}
+
+// settings builds the Settings Set and Map share from the Struct's options.
+func (m *Struct) settings() Settings {
+	return Settings{
 		TagOrder:         m.tags,
 		AllowEnvOverride: false,
 		AllowTagOverride: false,
 		EncodingTags:     m.encodingTags,
-	}, inputs)
+		Converters:       m.converters,
+		DurationUnit:     m.durationUnit,
+		Strict:           m.strict,
+	}
+}
+
+// Set populates the bound struct from inputs, resolving keys by tag priority
+// and applying `default:` tag values to fields left zero.
+func (m *Struct) Set(inputs map[string]any) error {
+	settings := m.settings()
+	if m.trackProvenance {
+		settings.Provenance = make(Provenance)
+	}
+
+	err := SetStructFields(m.structure, settings, inputs)
 	if err != nil {
 		return fmt.Errorf("error setting struct fields: %w", err)
 	}
+	m.provenance = settings.Provenance
 
 	return nil
 }
+
+// Provenance reports where each field's value came from in the last
+// successful Set. It is nil unless the Struct was built WithProvenance.
+func (m *Struct) Provenance() Provenance {
+	return m.provenance
+}
+
+// Map returns the bound struct's current field values keyed by tag priority,
+// as nested map sections or, when flatten is true, dotted keys. Feeding the
+// result back to Set reproduces the struct. See GetStructValues.
+func (m *Struct) Map(flatten bool) (map[string]any, error) {
+	values, err := GetStructValues(m.structure, m.settings(), flatten)
+	if err != nil {
+		return nil, fmt.Errorf("error getting struct values: %w", err)
+	}
+
+	return values, nil
+}
@@ -1,6 +1,10 @@
 package structs
 
-import "testing"
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
 
 // exercises the fluent public SDK (New + options + Validate/Set), which the
 // lower-level GetStructFields/SetStructFields/ValidateStructFields tests reach
@@ -57,6 +61,136 @@ func Test_Struct_Validate(t *testing.T) {
 	})
 }
 
+func Test_Struct_Check(t *testing.T) {
+	type target struct {
+		Format string `json:"format" rules:"oneof:json,yaml"`
+		Mode   string `json:"mode" rules:"required"`
+	}
+
+	s := New(&target{}, WithTags("json"))
+
+	requireNoError(t, s.Check(map[string]any{"format": "json", "mode": "fast"}))
+
+	err := s.Check(map[string]any{"format": "xml"})
+	var validationErrors ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		t.Fatalf("expected ValidationErrors, got %T: %v", err, err)
+	}
+	requireLen(t, validationErrors, 2)
+	requireEqual(t, FieldError{Field: "format", Rule: "oneof", Args: []string{"json", "yaml"}, Value: "xml", Message: "must be one of: json, yaml"}, validationErrors[0])
+	requireEqual(t, "mode", validationErrors[1].Field)
+
+	bad := New(target{}, WithTags("json"))
+	requireErrorIs(t, bad.Check(map[string]any{}), ErrInputPointer)
+}
+
+type valueDatabase struct {
+	DSN string `json:"dsn" rules:"required"`
+}
+
+type valueConfig struct {
+	Host     string         `json:"host" default:"localhost" rules:"required|hostname"`
+	Port     *int           `json:"port" rules:"between:1,65535"`
+	Mode     string         `json:"mode" default:"dev" rules:"oneof:dev,prod"`
+	Tags     []string       `json:"tags" rules:"max:2"`
+	Password string         `json:"password"`
+	Confirm  string         `json:"confirm" rules:"eqfield:Password"`
+	Database *valueDatabase `json:"database"`
+}
+
+func Test_Struct_ValidateValue(t *testing.T) {
+	t.Run("values assigned in code are validated, defaults are not consulted", func(t *testing.T) {
+		port := 0
+		cfg := &valueConfig{Port: &port, Mode: "staging", Tags: []string{"a", "b", "c"}, Password: "x"}
+
+		errs, err := New(cfg, WithTags("json")).ValidateValue()
+		requireNoError(t, err)
+		requireEqual(t, map[string][]string{
+			"host":    {"required"},
+			"port":    {"must be between 1 and 65535"},
+			"mode":    {"must be one of: dev, prod"},
+			"tags":    {"must have at most 2 items"},
+			"confirm": {"must equal Password"},
+		}, errs)
+	})
+
+	t.Run("zero scalars are values, not unset", func(t *testing.T) {
+		type target struct {
+			Port    int `json:"port" rules:"between:1,65535"`
+			Workers int `json:"workers" rules:"min:1"`
+		}
+		s := New(&target{}, WithTags("json"))
+
+		errs, err := s.ValidateValue()
+		requireNoError(t, err)
+		requireEqual(t, map[string][]string{
+			"port":    {"must be between 1 and 65535"},
+			"workers": {"must be at least 1"},
+		}, errs)
+
+		inputErrs, err := s.Validate(map[string]any{"port": 0, "workers": 0})
+		requireNoError(t, err)
+		requireEqual(t, inputErrs, errs)
+	})
+
+	t.Run("zero scalars are unset for required", func(t *testing.T) {
+		type target struct {
+			Port  int  `json:"port" rules:"required"`
+			Debug bool `json:"debug" rules:"required"`
+			Retry int  `json:"retry" rules:"required_without:port"`
+		}
+		s := New(&target{}, WithTags("json"))
+
+		errs, err := s.ValidateValue()
+		requireNoError(t, err)
+		inputErrs, err := s.Validate(map[string]any{})
+		requireNoError(t, err)
+		requireEqual(t, map[string][]string{
+			"port":  {"required"},
+			"debug": {"required"},
+			"retry": {"required when port is not set"},
+		}, inputErrs)
+		requireEqual(t, inputErrs, errs)
+
+		errs, err = New(&target{Port: 8080, Debug: true}, WithTags("json")).ValidateValue()
+		requireNoError(t, err)
+		requireEqual(t, map[string][]string{}, errs)
+	})
+
+	t.Run("a nil optional section is not validated", func(t *testing.T) {
+		errs, err := New(&valueConfig{Host: "localhost"}, WithTags("json")).ValidateValue()
+		requireNoError(t, err)
+		requireEqual(t, map[string][]string{}, errs)
+
+		errs, err = New(&valueConfig{Host: "localhost", Database: &valueDatabase{}}, WithTags("json")).ValidateValue()
+		requireNoError(t, err)
+		requireEqual(t, map[string][]string{"database.dsn": {"required"}}, errs)
+	})
+
+	t.Run("a struct populated by Set passes", func(t *testing.T) {
+		cfg := &valueConfig{}
+		s := New(cfg, WithTags("json"))
+		requireNoError(t, s.Set(map[string]any{"port": "8080", "database": map[string]any{"dsn": "postgres://"}}))
+
+		errs, err := s.ValidateValue()
+		requireNoError(t, err)
+		requireEqual(t, map[string][]string{}, errs)
+	})
+
+	t.Run("CheckValue returns ValidationErrors with the offending value", func(t *testing.T) {
+		cfg := &valueConfig{Host: "bad host", Database: &valueDatabase{DSN: "postgres://"}}
+
+		err := New(cfg, WithTags("json")).CheckValue()
+		var validationErrors ValidationErrors
+		if !errors.As(err, &validationErrors) {
+			t.Fatalf("expected ValidationErrors, got %T: %v", err, err)
+		}
+		requireEqual(t, ValidationErrors{
+			{Field: "host", Rule: "hostname", Value: "bad host", Message: "must be a valid hostname"},
+		}, validationErrors)
+	})
+}
+
 func Test_Struct_Set(t *testing.T) {
 	t.Run("sets fields and applies defaults", func(t *testing.T) {
 		type target struct {
@@ -79,6 +213,22 @@ func Test_Struct_Set(t *testing.T) {
 	})
 }
 
+func Test_Struct_WithConverter(t *testing.T) {
+	type target struct {
+		Price money `json:"price" default:"9.99"`
+	}
+	got := &target{}
+	s := New(got, WithConverter(reflect.TypeOf(money{}), parseMoney))
+
+	err := s.Set(map[string]any{})
+	requireNoError(t, err)
+	requireEqual(t, money{Cents: 999}, got.Price)
+
+	err = s.Set(map[string]any{"price": "1.50"})
+	requireNoError(t, err)
+	requireEqual(t, money{Cents: 150}, got.Price)
+}
+
 func Test_MapDefaultValues(t *testing.T) {
 	fields := []Field{
 		{Name: "Field1", Tags: map[string]string{"json": "field_1"}, Default: "d1"},
@@ -0,0 +1,111 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/toaweme/structs/utils"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// defaultTimeLayout is used for time.Time fields without a `layout:` tag.
+const defaultTimeLayout = time.RFC3339
+
+// setTimeValue sets time.Duration and time.Time fields, which would otherwise
+// go down the int64 and struct paths. A Duration is parsed with
+// time.ParseDuration, or counted in settings.DurationUnit when given a plain
+// number. A Time is parsed with layout, the field's `layout:` tag,
+// defaulting to RFC3339. handled is false for any other type.
+func setTimeValue(fieldName string, value any, fieldValue reflect.Value, layout string, settings Settings) (bool, error) {
+	if value == nil || !fieldValue.IsValid() {
+		return false, nil
+	}
+
+	switch fieldValue.Type() {
+	case durationType:
+		duration, err := toDuration(value, settings.DurationUnit)
+		if errors.Is(err, errDurationOverflow) {
+			return true, newOverflowError(fieldName, value, fieldValue)
+		}
+		if err != nil {
+			return true, fmt.Errorf("failed to set field[%s] duration: %w", fieldName, err)
+		}
+		fieldValue.SetInt(int64(duration))
+		return true, nil
+	case timeType:
+		t, err := toTime(value, layout)
+		if err != nil {
+			return true, fmt.Errorf("failed to set field[%s] time: %w", fieldName, err)
+		}
+		fieldValue.Set(reflect.ValueOf(t))
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// errDurationOverflow is returned by toDuration when a plain number counted in
+// the duration unit does not fit an int64 of nanoseconds.
+var errDurationOverflow = errors.New("duration overflows int64")
+
+func toDuration(value any, unit time.Duration) (time.Duration, error) {
+	if unit == 0 {
+		unit = time.Nanosecond
+	}
+
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		v = strings.TrimSpace(v)
+		if integer, err := strconv.ParseInt(v, 10, 64); err == nil {
+			if integer > math.MaxInt64/int64(unit) || integer < math.MinInt64/int64(unit) {
+				return 0, errDurationOverflow
+			}
+			return time.Duration(integer) * unit, nil
+		}
+		duration, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse duration value: %s: %w", v, err)
+		}
+		return duration, nil
+	default:
+		float, err := utils.ToFloat(value)
+		if err != nil {
+			return 0, err
+		}
+		nanos := float * float64(unit)
+		if utils.FloatOverflowsInt(nanos) {
+			return 0, errDurationOverflow
+		}
+		return time.Duration(nanos), nil
+	}
+}
+
+func toTime(value any, layout string) (time.Time, error) {
+	if layout == "" {
+		layout = defaultTimeLayout
+	}
+
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		t, err := time.Parse(layout, strings.TrimSpace(v))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse time value with layout %q: %w", layout, err)
+		}
+		return t, nil
+	default:
+		return time.Time{}, fmt.Errorf("unsupported time type: %T", value)
+	}
+}
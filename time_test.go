@@ -0,0 +1,96 @@
+package structs
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_SetField_Time(t *testing.T) {
+	type target struct {
+		Timeout  time.Duration   `json:"timeout" default:"30s"`
+		Retry    time.Duration   `json:"retry"`
+		Backoffs []time.Duration `json:"backoffs"`
+		Started  time.Time       `json:"started"`
+		Birthday time.Time       `json:"birthday" layout:"2006-01-02" default:"2000-01-31"`
+		Windows  []time.Time     `json:"windows" layout:"15:04"`
+		Deadline *time.Time      `json:"deadline"`
+	}
+	settings := Settings{TagOrder: DefaultTags, EncodingTags: DefaultEncodingTags}
+
+	t.Run("parses durations and times", func(t *testing.T) {
+		got := &target{}
+		err := SetStructFields(got, settings, map[string]any{
+			"retry":    "1m30s",
+			"backoffs": "1s, 5s, 1m",
+			"started":  "2026-10-17T12:00:00Z",
+			"windows":  "09:00,17:30",
+			"deadline": "2026-12-31T23:59:59Z",
+		})
+		requireNoError(t, err)
+		requireEqual(t, 30*time.Second, got.Timeout)
+		requireEqual(t, 90*time.Second, got.Retry)
+		requireEqual(t, []time.Duration{time.Second, 5 * time.Second, time.Minute}, got.Backoffs)
+		requireEqual(t, time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC), got.Started)
+		requireEqual(t, time.Date(2000, 1, 31, 0, 0, 0, 0, time.UTC), got.Birthday)
+		requireEqual(t, []time.Time{
+			time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC),
+			time.Date(0, 1, 1, 17, 30, 0, 0, time.UTC),
+		}, got.Windows)
+		requireNotNil(t, got.Deadline)
+		requireEqual(t, time.Date(2026, 12, 31, 23, 59, 59, 0, time.UTC), *got.Deadline)
+	})
+
+	t.Run("plain integers count in the duration unit", func(t *testing.T) {
+		got := &target{}
+		err := SetStructFields(got, settings, map[string]any{"retry": 5})
+		requireNoError(t, err)
+		requireEqual(t, 5*time.Nanosecond, got.Retry)
+
+		seconds := settings
+		seconds.DurationUnit = time.Second
+		err = SetStructFields(got, seconds, map[string]any{"retry": "5", "backoffs": []any{1, 2}})
+		requireNoError(t, err)
+		requireEqual(t, 5*time.Second, got.Retry)
+		requireEqual(t, []time.Duration{time.Second, 2 * time.Second}, got.Backoffs)
+	})
+
+	t.Run("durations beyond int64 overflow", func(t *testing.T) {
+		hours := settings
+		hours.DurationUnit = time.Hour
+		for _, input := range []any{"3000000", -3000000, 1e20} {
+			err := SetStructFields(&target{}, hours, map[string]any{"retry": input})
+			var overflow *OverflowError
+			if !errors.As(err, &overflow) {
+				t.Fatalf("expected *OverflowError for %v, got %v", input, err)
+			}
+			requireEqual(t, "Retry", overflow.Field)
+		}
+	})
+
+	t.Run("time.Time is a leaf, not a nested struct", func(t *testing.T) {
+		fields, err := GetStructFields(&target{}, nil, DefaultEncodingTags)
+		requireNoError(t, err)
+		if fields[3].Fields != nil {
+			t.Fatalf("time.Time field should have no nested fields, got %d", len(fields[3].Fields))
+		}
+	})
+
+	t.Run("bad inputs name the field", func(t *testing.T) {
+		err := SetStructFields(&target{}, settings, map[string]any{"retry": "soon"})
+		requireErrorContains(t, err, "field[Retry]")
+
+		err = SetStructFields(&target{}, settings, map[string]any{"birthday": "31/01/2000"})
+		requireErrorContains(t, err, "field[Birthday]")
+	})
+}
+
+func Test_Struct_WithDurationUnit(t *testing.T) {
+	type target struct {
+		Timeout time.Duration `json:"timeout" default:"10"`
+	}
+	got := &target{}
+	err := New(got, WithDurationUnit(time.Millisecond)).Set(map[string]any{})
+	requireNoError(t, err)
+	requireEqual(t, 10*time.Millisecond, got.Timeout)
+}
@@ -0,0 +1,240 @@
+package structs
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+)
+
+// UsageFormat selects how Usage renders a struct's options.
+type UsageFormat int
+
+const (
+	// UsageText renders aligned plain text for a terminal, like a --help screen.
+	UsageText UsageFormat = iota
+	// UsageMarkdown renders a Markdown table per group, for READMEs and docs.
+	UsageMarkdown
+)
+
+// usageOptionsTitle heads the group of top-level fields.
+const usageOptionsTitle = "Options"
+
+// usageRow is one documented field: how to set it and what it means.
+type usageRow struct {
+	name       string
+	flags      string
+	env        string
+	typ        string
+	defaultVal string
+	choices    []string
+	help       string
+}
+
+// label is how the text output names a row: its flags, else its env key,
+// else its Go field name.
+func (r usageRow) label() string {
+	if r.flags != "" {
+		return r.flags
+	}
+	if r.env != "" {
+		return r.env
+	}
+	return r.name
+}
+
+// usageGroup is the rows of one struct level, titled by its path.
+type usageGroup struct {
+	title string
+	help  string
+	rows  []usageRow
+}
+
+// Usage writes help text for structure (a pointer to a struct) to w: each
+// field's flag names (`arg:`/`short:`), env key (the "_"-glued FQN env key
+// for nested fields), type, default, `oneof` choices and `help:` text. Fields
+// are grouped by the struct they are declared in, top-level fields first under
+// "Options", then each nested struct under its own path. Only fields with an
+// arg, short, env or help tag are listed.
+func Usage(w io.Writer, structure any, format UsageFormat) error {
+	fields, err := GetStructFields(structure, nil, DefaultEncodingTags)
+	if err != nil {
+		return err
+	}
+
+	groups := collectUsageGroups(fields, usageGroup{title: usageOptionsTitle}, nil)
+
+	switch format {
+	case UsageMarkdown:
+		return writeMarkdownUsage(w, groups)
+	case UsageText:
+		return writeTextUsage(w, groups)
+	default:
+		return fmt.Errorf("unsupported usage format: %d", format)
+	}
+}
+
+// collectUsageGroups appends group, filled with fields' documented rows, to
+// groups, followed depth-first by a group per nested struct.
+func collectUsageGroups(fields []Field, group usageGroup, groups []usageGroup) []usageGroup {
+	nested := make([]usageGroup, 0)
+	for _, field := range fields {
+		if field.Fields != nil {
+			named := field
+			if field.FQN != nil {
+				named = *field.FQN
+			}
+			title := named.Tags[argTag]
+			if title == "" {
+				title = named.Name
+			}
+			nested = collectUsageGroups(field.Fields, usageGroup{title: title, help: field.Tags[helpTag]}, nested)
+			continue
+		}
+
+		if row, ok := newUsageRow(field); ok {
+			group.rows = append(group.rows, row)
+		}
+	}
+
+	if len(group.rows) > 0 {
+		groups = append(groups, group)
+	}
+	return append(groups, nested...)
+}
+
+func newUsageRow(field Field) (usageRow, bool) {
+	flags := make([]string, 0, 2)
+	for _, f := range getFlags([]Field{field}, Settings{}) {
+		if f.short != "" {
+			flags = append(flags, "-"+f.short)
+		}
+		if f.name != "" {
+			flags = append(flags, "--"+f.name)
+		}
+	}
+	env := fieldEnvKey(field)
+	help := field.Tags[helpTag]
+	if len(flags) == 0 && env == "" && help == "" {
+		return usageRow{}, false
+	}
+
+	row := usageRow{
+		name:       field.Name,
+		flags:      strings.Join(flags, ", "),
+		env:        env,
+		typ:        usageType(field),
+		defaultVal: field.Default,
+		help:       help,
+	}
+	for _, rule := range field.Rules {
+		if rule.Name == "oneof" {
+			row.choices = rule.Args
+		}
+	}
+	return row, true
+}
+
+// usageType names the value a field takes, e.g. "string", "[]int",
+// "time.Duration". Pointers are shown as the type they point to.
+func usageType(field Field) string {
+	if !field.Value.IsValid() {
+		return field.Type
+	}
+	typ := field.Value.Type()
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ.String()
+}
+
+func writeTextUsage(w io.Writer, groups []usageGroup) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Fprintln(tw)
+		}
+		fmt.Fprintf(tw, "%s:\n", group.title)
+		if group.help != "" {
+			fmt.Fprintf(tw, "  %s\n", group.help)
+		}
+
+		for _, row := range group.rows {
+			details := make([]string, 0, 3)
+			if row.defaultVal != "" {
+				details = append(details, "default: "+row.defaultVal)
+			}
+			if len(row.choices) > 0 {
+				details = append(details, "one of: "+strings.Join(row.choices, ", "))
+			}
+			if row.env != "" && row.flags != "" {
+				details = append(details, "env: "+row.env)
+			}
+
+			description := row.help
+			if len(details) > 0 {
+				description = strings.TrimSpace(description + " (" + strings.Join(details, "; ") + ")")
+			}
+			if description == "" {
+				fmt.Fprintf(tw, "  %s\t%s\n", row.label(), row.typ)
+				continue
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\n", row.label(), row.typ, description)
+		}
+	}
+
+	return tw.Flush()
+}
+
+func writeMarkdownUsage(w io.Writer, groups []usageGroup) error {
+	var b strings.Builder
+	for i, group := range groups {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "### %s\n\n", group.title)
+		if group.help != "" {
+			fmt.Fprintf(&b, "%s\n\n", group.help)
+		}
+
+		b.WriteString("| Flag | Env | Type | Default | Choices | Description |\n")
+		b.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+		for _, row := range group.rows {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n",
+				markdownCode(row.flags),
+				markdownCode(row.env),
+				markdownCode(row.typ),
+				markdownCode(row.defaultVal),
+				markdownCode(strings.Join(row.choices, ", ")),
+				markdownCell(row.help),
+			)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownCode wraps a non-empty table cell in backticks.
+func markdownCode(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + markdownCell(s) + "`"
+}
+
+// markdownCell escapes the pipes that would otherwise end a table cell.
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
+
+// Usage writes help text for the bound struct to w. See Usage.
+func (m *Struct) Usage(w io.Writer, format UsageFormat) error {
+	err := Usage(w, m.structure, format)
+	if err != nil {
+		return fmt.Errorf("error writing struct usage: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package structs
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+type usageDatabase struct {
+	DSN     string        `arg:"dsn" env:"DSN" help:"Connection string" rules:"required"`
+	Timeout time.Duration `arg:"timeout" default:"5s"`
+}
+
+type usageConfig struct {
+	Output   string        `arg:"output" short:"o" default:"table" help:"Output format" rules:"oneof:table,json"`
+	Verbose  bool          `arg:"verbose" short:"v" help:"Verbose logging"`
+	Token    *string       `env:"TOKEN" help:"API token"`
+	Tags     []string      `arg:"tag"`
+	Internal string        `json:"internal"`
+	Database usageDatabase `arg:"db" env:"DB" help:"Primary database"`
+}
+
+func Test_Usage(t *testing.T) {
+	t.Run("plain text", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := Usage(&buf, &usageConfig{}, UsageText)
+		requireNoError(t, err)
+		requireEqual(t, `Options:
+  -o, --output   string  Output format (default: table; one of: table, json)
+  -v, --verbose  bool    Verbose logging
+  TOKEN          string  API token
+  --tag          []string
+
+db:
+  Primary database
+  --db.dsn      string         Connection string (env: DB_DSN)
+  --db.timeout  time.Duration  (default: 5s)
+`, buf.String())
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := New(&usageConfig{}).Usage(&buf, UsageMarkdown)
+		requireNoError(t, err)
+		requireEqual(t, "### Options\n\n"+
+			"| Flag | Env | Type | Default | Choices | Description |\n"+
+			"| --- | --- | --- | --- | --- | --- |\n"+
+			"| `-o, --output` |  | `string` | `table` | `table, json` | Output format |\n"+
+			"| `-v, --verbose` |  | `bool` |  |  | Verbose logging |\n"+
+			"|  | `TOKEN` | `string` |  |  | API token |\n"+
+			"| `--tag` |  | `[]string` |  |  |  |\n"+
+			"\n### db\n\n"+
+			"Primary database\n\n"+
+			"| Flag | Env | Type | Default | Choices | Description |\n"+
+			"| --- | --- | --- | --- | --- | --- |\n"+
+			"| `--db.dsn` | `DB_DSN` | `string` |  |  | Connection string |\n"+
+			"| `--db.timeout` |  | `time.Duration` | `5s` |  |  |\n", buf.String())
+	})
+
+	t.Run("non-pointer structure errors", func(t *testing.T) {
+		err := Usage(&bytes.Buffer{}, usageConfig{}, UsageText)
+		requireErrorIs(t, err, ErrInputPointer)
+	})
+}
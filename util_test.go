@@ -1,7 +1,10 @@
 package structs
 
 import (
+	"errors"
+	"math"
 	"reflect"
+	"strconv"
 	"testing"
 
 	"github.com/toaweme/structs/utils"
@@ -125,6 +128,9 @@ func Test_ToInt(t *testing.T) {
 		{name: "whole float64", input: float64(3), want: 3},
 		{name: "fractional float32 errors", input: float32(2.5), wantErr: true},
 		{name: "fractional float64 errors", input: float64(3.5), wantErr: true},
+		{name: "uint64 beyond int errors", input: uint64(math.MaxUint64), wantErr: true},
+		{name: "float64 beyond int errors", input: 1e20, wantErr: true},
+		{name: "float32 beyond int errors", input: float32(-1e20), wantErr: true},
 		{name: "numeric string", input: "42", want: 42},
 		{name: "non-numeric string errors", input: "x", wantErr: true},
 		{name: "bool errors", input: true, wantErr: true},
@@ -149,6 +155,83 @@ func Test_ToInt(t *testing.T) {
 	}
 }
 
+func Test_ToInt64_Range(t *testing.T) {
+	got, err := utils.ToInt64("-9000000000")
+	if err != nil || got != -9000000000 {
+		t.Fatalf("ToInt64 = %d, %v, want -9000000000", got, err)
+	}
+
+	for _, input := range []any{"99999999999999999999", uint64(math.MaxUint64), 1e20} {
+		_, err := utils.ToInt64(input)
+		if !errors.Is(err, strconv.ErrRange) {
+			t.Fatalf("ToInt64(%v) error = %v, want strconv.ErrRange", input, err)
+		}
+	}
+}
+
+func Test_ToUint64(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   any
+		want    uint64
+		wantErr bool
+	}{
+		{name: "uint", input: uint(5), want: 5},
+		{name: "uint16", input: uint16(5), want: 5},
+		{name: "uint64", input: uint64(1 << 63), want: 1 << 63},
+		{name: "int", input: 7, want: 7},
+		{name: "int64", input: int64(7), want: 7},
+		{name: "negative int errors", input: -1, wantErr: true},
+		{name: "negative int8 errors", input: int8(-1), wantErr: true},
+		{name: "whole float64", input: float64(3), want: 3},
+		{name: "fractional float64 errors", input: float64(3.5), wantErr: true},
+		{name: "negative float32 errors", input: float32(-2), wantErr: true},
+		{name: "numeric string", input: "42", want: 42},
+		{name: "negative string errors", input: "-42", wantErr: true},
+		{name: "non-numeric string errors", input: "x", wantErr: true},
+		{name: "bool errors", input: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := utils.ToUint64(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ToUint64(%v) = %d, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ToUint64(%v) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Fatalf("ToUint64(%v) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ToUint_Range(t *testing.T) {
+	if got, err := utils.ToUint8("255"); err != nil || got != 255 {
+		t.Fatalf("ToUint8(255) = %d, %v, want 255", got, err)
+	}
+	if _, err := utils.ToUint8(256); err == nil {
+		t.Fatalf("ToUint8(256) want out of range error")
+	}
+	if got, err := utils.ToUint16(65535); err != nil || got != 65535 {
+		t.Fatalf("ToUint16(65535) = %d, %v, want 65535", got, err)
+	}
+	if _, err := utils.ToUint16("65536"); err == nil {
+		t.Fatalf("ToUint16(65536) want out of range error")
+	}
+	if _, err := utils.ToUint32(int64(1) << 32); err == nil {
+		t.Fatalf("ToUint32(1<<32) want out of range error")
+	}
+	if got, err := utils.ToUint(10); err != nil || got != 10 {
+		t.Fatalf("ToUint(10) = %d, %v, want 10", got, err)
+	}
+}
+
 func Test_ToFloat(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -259,6 +342,7 @@ func Test_ToAnySlice(t *testing.T) {
 		{name: "non-empty string yields single element", input: "a", want: []any{"a"}},
 		{name: "string slice", input: []string{"a", "b"}, want: []any{"a", "b"}},
 		{name: "int slice via reflection", input: []int{1, 2, 3}, want: []any{1, 2, 3}},
+		{name: "array via reflection", input: [2]float64{1.5, 2}, want: []any{1.5, 2.0}},
 		{name: "non-slice errors", input: 5, wantErr: true},
 	}
 
@@ -4,6 +4,7 @@ package utils
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
@@ -62,42 +63,56 @@ func ParseBool(val string) bool {
 	}
 }
 
-// ToInt converts a numeric or numeric-string value to an int.
-// Floats with a fractional part and unparseable strings return an error.
+// ToInt converts a numeric or numeric-string value to an int, see ToInt64.
+// Values outside the int range return an error wrapping strconv.ErrRange.
 func ToInt(value any) (int, error) {
+	integer, err := ToInt64(value)
+	if err != nil {
+		return 0, err
+	}
+	if integer > math.MaxInt || integer < math.MinInt {
+		return 0, fmt.Errorf("int value %d out of range: %w", integer, strconv.ErrRange)
+	}
+	return int(integer), nil
+}
+
+// ToInt64 converts a numeric or numeric-string value to an int64.
+// Values outside the int64 range return an error wrapping strconv.ErrRange;
+// floats with a fractional part and unparseable strings return an error.
+func ToInt64(value any) (int64, error) {
 	switch v := value.(type) {
 	case int:
-		return v, nil
+		return int64(v), nil
 	case int8:
-		return int(v), nil
+		return int64(v), nil
 	case int16:
-		return int(v), nil
+		return int64(v), nil
 	case int32:
-		return int(v), nil
+		return int64(v), nil
 	case int64:
-		return int(v), nil
+		return v, nil
 	case uint:
-		return int(v), nil
+		if uint64(v) > math.MaxInt64 {
+			return 0, fmt.Errorf("failed to parse int from uint: %v: %w", v, strconv.ErrRange)
+		}
+		return int64(v), nil
 	case uint8:
-		return int(v), nil
+		return int64(v), nil
 	case uint16:
-		return int(v), nil
+		return int64(v), nil
 	case uint32:
-		return int(v), nil
+		return int64(v), nil
 	case uint64:
-		return int(v), nil
-	case float32:
-		if float32(int(v)) != v {
-			return 0, fmt.Errorf("failed to parse int from float32 with fractional part: %v", v)
+		if v > math.MaxInt64 {
+			return 0, fmt.Errorf("failed to parse int from uint64: %v: %w", v, strconv.ErrRange)
 		}
-		return int(v), nil
+		return int64(v), nil
+	case float32:
+		return floatToInt64(float64(v))
 	case float64:
-		if float64(int(v)) != v {
-			return 0, fmt.Errorf("failed to parse int from float64 with fractional part: %v", v)
-		}
-		return int(v), nil
+		return floatToInt64(v)
 	case string:
-		integer, err := strconv.Atoi(v)
+		integer, err := strconv.ParseInt(v, 10, 64)
 		if err != nil {
 			return 0, fmt.Errorf("failed to parse int value: %s: %w", v, err)
 		}
@@ -107,6 +122,108 @@ func ToInt(value any) (int, error) {
 	}
 }
 
+func floatToInt64(v float64) (int64, error) {
+	if FloatOverflowsInt(v) {
+		return 0, fmt.Errorf("failed to parse int from float: %v: %w", v, strconv.ErrRange)
+	}
+	if v != math.Trunc(v) {
+		return 0, fmt.Errorf("failed to parse int from float with fractional part: %v", v)
+	}
+	return int64(v), nil
+}
+
+// FloatOverflowsInt reports whether v lies outside the int64 range, where a
+// plain int conversion would silently produce a wrong value.
+func FloatOverflowsInt(v float64) bool {
+	return v >= math.MaxInt64 || v < math.MinInt64
+}
+
+// ToUint64 converts a non-negative numeric or numeric-string value to a uint64.
+// Negative values, floats with a fractional part and unparseable strings
+// return an error.
+func ToUint64(value any) (uint64, error) {
+	switch v := value.(type) {
+	case uint:
+		return uint64(v), nil
+	case uint8:
+		return uint64(v), nil
+	case uint16:
+		return uint64(v), nil
+	case uint32:
+		return uint64(v), nil
+	case uint64:
+		return v, nil
+	case uintptr:
+		return uint64(v), nil
+	case int, int8, int16, int32, int64:
+		integer := reflect.ValueOf(v).Int()
+		if integer < 0 {
+			return 0, fmt.Errorf("failed to parse uint from negative value: %v", v)
+		}
+		return uint64(integer), nil
+	case float32:
+		return floatToUint64(float64(v))
+	case float64:
+		return floatToUint64(v)
+	case string:
+		integer, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse uint value: %s: %w", v, err)
+		}
+		return integer, nil
+	default:
+		return 0, fmt.Errorf("failed to parse uint from type: %T, value: %v", value, value)
+	}
+}
+
+func floatToUint64(v float64) (uint64, error) {
+	if v < 0 {
+		return 0, fmt.Errorf("failed to parse uint from negative value: %v", v)
+	}
+	if v != math.Trunc(v) {
+		return 0, fmt.Errorf("failed to parse uint from float with fractional part: %v", v)
+	}
+	if v >= math.MaxUint64 {
+		return 0, fmt.Errorf("failed to parse uint from float: %v: %w", v, strconv.ErrRange)
+	}
+	return uint64(v), nil
+}
+
+// ToUint converts a value to a uint, see ToUint64.
+func ToUint(value any) (uint, error) {
+	integer, err := toUintMax(value, math.MaxUint)
+	return uint(integer), err //nolint:gosec // range checked by toUintMax
+}
+
+// ToUint8 converts a value to a uint8, failing when it is out of range.
+func ToUint8(value any) (uint8, error) {
+	integer, err := toUintMax(value, math.MaxUint8)
+	return uint8(integer), err //nolint:gosec // range checked by toUintMax
+}
+
+// ToUint16 converts a value to a uint16, failing when it is out of range.
+func ToUint16(value any) (uint16, error) {
+	integer, err := toUintMax(value, math.MaxUint16)
+	return uint16(integer), err //nolint:gosec // range checked by toUintMax
+}
+
+// ToUint32 converts a value to a uint32, failing when it is out of range.
+func ToUint32(value any) (uint32, error) {
+	integer, err := toUintMax(value, math.MaxUint32)
+	return uint32(integer), err //nolint:gosec // range checked by toUintMax
+}
+
+func toUintMax(value any, limit uint64) (uint64, error) {
+	integer, err := ToUint64(value)
+	if err != nil {
+		return 0, err
+	}
+	if integer > limit {
+		return 0, fmt.Errorf("uint value %d out of range, max: %d", integer, limit)
+	}
+	return integer, nil
+}
+
 // ToString converts a string, int, or float value to its string form
 func ToString(value any) (string, error) {
 	switch v := value.(type) {
@@ -124,7 +241,7 @@ func ToString(value any) (string, error) {
 // ToAnySlice converts a slice value to []any.
 // []any and []string are handled directly
 // a non-empty string becomes a single-element slice
-// any other slice is converted element-wise via reflection.
+// any other slice or array is converted element-wise via reflection.
 // Non-slice types return an error.
 func ToAnySlice(value any) ([]any, error) {
 	switch v := value.(type) {
@@ -143,7 +260,7 @@ func ToAnySlice(value any) ([]any, error) {
 		return anySlice, nil
 	default:
 		val := reflect.ValueOf(value)
-		if val.Kind() != reflect.Slice {
+		if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
 			return nil, fmt.Errorf("unsupported slice type: %T: %v", value, value)
 		}
 
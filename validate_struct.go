@@ -3,41 +3,432 @@ package structs
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 )
 
 // ValidateStructFields runs each field's rules (looked up in ruleFuncs) against
 // values and returns field name to error messages. Field names are resolved by
 // tagPriority, then overridden by the validationTag value when a field carries
-// one. An empty result means everything passed.
+// one. Nested struct fields are validated recursively and reported under their
+// dotted FQN (e.g. "database.dsn"). An empty result means everything passed.
+// See ValidateStructFieldErrors for the same result as typed ValidationErrors.
 func ValidateStructFields(ruleFuncs map[string]RuleFunc, structFields []Field, values map[string]any, validationTag string, tagPriority ...string) (map[string][]string, error) {
-	validationErrors := make(map[string][]string)
+	validationErrors, err := ValidateStructFieldErrors(ruleFuncs, structFields, values, validationTag, tagPriority...)
+	if err != nil {
+		return nil, err
+	}
+
+	return validationErrors.Map(), nil
+}
+
+// ValidateStructFieldErrors is ValidateStructFields returning one FieldError
+// per failed rule, carrying the rule name, its args and the offending value
+// alongside the message. Messages come from DefaultMessages, or a field's
+// `msg:` tag. An empty result means everything passed.
+func ValidateStructFieldErrors(ruleFuncs map[string]RuleFunc, structFields []Field, values map[string]any, validationTag string, tagPriority ...string) (ValidationErrors, error) {
+	v := validator{
+		ruleFuncs:     ruleFuncs,
+		validationTag: validationTag,
+		tagPriority:   tagPriority,
+		messages:      DefaultMessages.messages(DefaultLocale),
+		encodingTags:  DefaultEncodingTags,
+	}
+
+	return v.validate(structFields, values)
+}
+
+// validator carries what a validation run needs across nested fields.
+type validator struct {
+	ruleFuncs     map[string]RuleFunc
+	validationTag string
+	tagPriority   []string
+	// messages are the templates failures are reported with, by rule name.
+	messages Messages
+	// root is the whole field tree, where cross-field rules look up the
+	// fields they reference.
+	root []Field
+	// current validates the fields' values instead of inputs, see
+	// Struct.ValidateValue.
+	current bool
+	// present holds, in current mode, the values of the fields that are set:
+	// those not zero. The presence rules judge by it.
+	present map[string]any
+	// encodingTags are used to reflect over the struct elements a dive
+	// rule validates.
+	encodingTags []string
+}
+
+func (v validator) validate(structFields []Field, values map[string]any) (ValidationErrors, error) {
+	v.root = structFields
+	if v.current {
+		values = make(map[string]any)
+		v.present = make(map[string]any)
+		v.currentValues(structFields, values, v.present)
+	}
+	validationErrors := make(ValidationErrors, 0)
+	err := v.validateFields(structFields, values, &validationErrors)
+	if err != nil {
+		return nil, err
+	}
+
+	return validationErrors, nil
+}
+
+func (v validator) validateFields(structFields []Field, values map[string]any, validationErrors *ValidationErrors) error {
 	for _, structField := range structFields {
-		for _, rule := range structField.Rules {
-			fieldName := structField.Name
-			tags := structField.Tags
-			fieldNameByTagPriority := getTagByPriority(tags, tagPriority)
-			if fieldNameByTagPriority != "" {
-				fieldName = fieldNameByTagPriority
+		// a nested field is named and looked up by its fully-qualified view
+		named := fqnView(structField)
+
+		fieldName := v.fieldName(named)
+		tags := named.Tags
+
+		// a set field's default has already been applied
+		defaultValue := structField.Default
+		if v.current {
+			defaultValue = ""
+		}
+
+		fieldValues := values
+		if len(structField.Rules) > 0 {
+			fieldValues = resolveFieldInput(named, fieldName, values, v.tagPriority)
+		}
+
+		fieldRules, keyRules, elementRules, dive := splitDive(structField.Rules)
+		for _, rule := range fieldRules {
+			ruleFieldValues, referenceValues := fieldValues, values
+			if byReference, ok := presenceRules[rule.Name]; ok && v.current {
+				ruleFieldValues = v.present
+				if byReference {
+					referenceValues = v.present
+				}
 			}
 
-			fieldValidationRules, err := validateRule(ruleFuncs, rule, fieldName, values, structField.Default, structField.Value)
+			ruleValues, err := v.withReferences(rule, structFields, ruleFieldValues, referenceValues)
 			if err != nil {
-				return nil, fmt.Errorf("error running validator function for rule '%s' field '%s': %w", rule.Name, fieldName, err)
+				return fmt.Errorf("error resolving rule '%s' field '%s': %w", rule.Name, fieldName, err)
 			}
 
-			for errorFieldName, errorMessages := range fieldValidationRules {
-				if fieldNameByValidationTag, ok := tags[validationTag]; ok {
-					errorFieldName = fieldNameByValidationTag
-				}
-				if _, ok := validationErrors[errorFieldName]; !ok {
-					validationErrors[errorFieldName] = make([]string, 0)
-				}
-				validationErrors[errorFieldName] = append(validationErrors[errorFieldName], errorMessages...)
+			ruleDefault := defaultValue
+			if countRules[rule.Name] {
+				ruleValues, ruleDefault = countInput(structField, fieldName, ruleValues, defaultValue)
+			}
+
+			err = v.applyRule(structField, rule, fieldName, tags[v.validationTag], ruleValues, ruleDefault, structField.Value, validationErrors)
+			if err != nil {
+				return err
+			}
+		}
+
+		if dive {
+			err := v.validateElements(structField, fieldName, diveInput(fieldValues, fieldName, defaultValue), keyRules, elementRules, validationErrors)
+			if err != nil {
+				return fmt.Errorf("error validating elements of field '%s': %w", fieldName, err)
+			}
+		}
+
+		// a nil optional *Struct has no current section to validate
+		if structField.Fields != nil && !(v.current && structField.pending.IsValid()) {
+			err := v.validateFields(structField.Fields, values, validationErrors)
+			if err != nil {
+				return err
 			}
 		}
 	}
 
-	return validationErrors, nil
+	return nil
+}
+
+// countInput returns values with a slice, array or map field's flat string
+// input, else its default, split into items on the field's sep tag as Set
+// splits it, so count rules measure what Set would store. Other fields and
+// inputs are returned unchanged; the caller's map is never mutated.
+func countInput(structField Field, fieldName string, values map[string]any, defaultValue string) (map[string]any, string) {
+	if !structField.Value.IsValid() {
+		return values, defaultValue
+	}
+	kind := structField.Value.Kind()
+	if kind != reflect.Slice && kind != reflect.Array && kind != reflect.Map {
+		return values, defaultValue
+	}
+
+	input, ok := values[fieldName]
+	if !ok || input == nil {
+		if defaultValue == "" {
+			return values, defaultValue
+		}
+		input = defaultValue
+	}
+	if s, isStr := input.(string); !isStr || strings.TrimSpace(s) == "" {
+		return values, defaultValue
+	}
+
+	var items any
+	if kind == reflect.Map {
+		pairs, err := splitMapInput(structField, input)
+		if err != nil {
+			// a malformed one is left for Set to report
+			return values, defaultValue
+		}
+		items = pairs
+	} else {
+		items = splitSliceInput(structField, Settings{}, input)
+	}
+
+	valuesCopy := make(map[string]any, len(values)+1)
+	for k, value := range values {
+		valuesCopy[k] = value
+	}
+	valuesCopy[fieldName] = items
+
+	return valuesCopy, ""
+}
+
+// fieldName is the key a field's input is read from and its failures are
+// reported under: its tag by priority, else its Go name.
+func (v validator) fieldName(named Field) string {
+	if name := getTagByPriority(named.Tags, v.tagPriority); name != "" {
+		return name
+	}
+	return named.Name
+}
+
+// currentValues puts each field's current value in values under its key, the
+// way an input for it would be. Only nil pointers, maps and slices are left
+// out, so they read as unset; a zero scalar is a value like any other, which
+// measure and format rules check. present gets the fields that are not zero,
+// for the presence rules. Pointers are dereferenced, and a nil
+// pointer-to-struct's fields are skipped.
+func (v validator) currentValues(structFields []Field, values, present map[string]any) {
+	for _, structField := range structFields {
+		if structField.pending.IsValid() {
+			continue
+		}
+		if structField.Value.IsValid() && !isNilValue(structField.Value) && structField.Value.CanInterface() {
+			key := v.fieldName(fqnView(structField))
+			values[key] = reflect.Indirect(structField.Value).Interface()
+			if !structField.Value.IsZero() {
+				present[key] = values[key]
+			}
+		}
+		if structField.Fields != nil {
+			v.currentValues(structField.Fields, values, present)
+		}
+	}
+}
+
+// isNilValue reports whether value is a nil pointer, map, slice or interface.
+func isNilValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return value.IsNil()
+	default:
+		return false
+	}
+}
+
+// withReferences returns fieldValues with the value of each field a
+// cross-field rule names (see fieldRuleArgs) available under the arg as
+// written, resolved from values the way Set would: input, else default. The
+// caller's map is never mutated.
+func (v validator) withReferences(rule Rule, siblings []Field, fieldValues, values map[string]any) (map[string]any, error) {
+	fieldArgs, ok := fieldRuleArgs[rule.Name]
+	if !ok {
+		return fieldValues, nil
+	}
+
+	ruleValues := make(map[string]any, len(fieldValues))
+	for k, value := range fieldValues {
+		ruleValues[k] = value
+	}
+	for i, name := range rule.Args {
+		if fieldArgs >= 0 && i >= fieldArgs {
+			break
+		}
+
+		field, named, found := v.findReference(name, siblings)
+		if !found {
+			return nil, fmt.Errorf("no field named %q", name)
+		}
+
+		delete(ruleValues, name)
+		if _, value, found := findFieldInput(named, values, v.tagPriority); found {
+			ruleValues[name] = value
+		} else if field.Default != "" && !v.current {
+			ruleValues[name] = field.Default
+		}
+	}
+
+	return ruleValues, nil
+}
+
+// findReference finds the field name refers to, and its fully-qualified view:
+// first among siblings by Go name or tag, then anywhere in the tree by Go
+// name, tag, or their dotted FQN forms.
+func (v validator) findReference(name string, siblings []Field) (Field, Field, bool) {
+	for _, sibling := range siblings {
+		if v.refersTo(sibling, name) {
+			return sibling, fqnView(sibling), true
+		}
+	}
+
+	return v.findInTree(name, v.root)
+}
+
+func (v validator) findInTree(name string, fields []Field) (Field, Field, bool) {
+	for _, field := range fields {
+		named := fqnView(field)
+		if v.refersTo(named, name) {
+			return field, named, true
+		}
+		if field.Fields != nil {
+			if found, foundNamed, ok := v.findInTree(name, field.Fields); ok {
+				return found, foundNamed, true
+			}
+		}
+	}
+
+	return Field{}, Field{}, false
+}
+
+// refersTo reports whether name is field's Go name or one of its name tags.
+func (v validator) refersTo(field Field, name string) bool {
+	if field.Name == name || field.Tags[envValueTag] == name {
+		return true
+	}
+	for _, tag := range v.tagPriority {
+		if value, ok := field.Tags[tag]; ok && value == name {
+			return true
+		}
+	}
+	return false
+}
+
+// fqnView is a nested field's fully-qualified view, or the field itself at
+// the top level.
+func fqnView(field Field) Field {
+	if field.FQN != nil {
+		return *field.FQN
+	}
+	return field
+}
+
+// message picks the message for a failed rule: the field's `msg:` tag, else
+// the rule's template, else the message the rule itself reported.
+func (v validator) message(structField Field, fieldError FieldError) string {
+	if template, ok := structField.Tags[msgTag]; ok && template != "" {
+		return renderMessage(template, fieldError)
+	}
+	if template, ok := v.messages[fieldError.Rule]; ok {
+		return renderMessage(template, fieldError)
+	}
+	return fieldError.Message
+}
+
+// applyRule runs rule for fieldName and appends its failures, reported under
+// reportAs when it is set (a field's validation tag).
+func (v validator) applyRule(structField Field, rule Rule, fieldName, reportAs string, values map[string]any, defaultValue string, fieldValue reflect.Value, validationErrors *ValidationErrors) error {
+	fieldValidationRules, err := validateRule(v.ruleFuncs, rule, fieldName, values, defaultValue, fieldValue)
+	if err != nil {
+		return fmt.Errorf("error running validator function for rule '%s' field '%s': %w", rule.Name, fieldName, err)
+	}
+
+	// a rule may report under several names; keep their order stable
+	errorFieldNames := make([]string, 0, len(fieldValidationRules))
+	for errorFieldName := range fieldValidationRules {
+		errorFieldNames = append(errorFieldNames, errorFieldName)
+	}
+	sort.Strings(errorFieldNames)
+
+	for _, errorFieldName := range errorFieldNames {
+		errorMessages := fieldValidationRules[errorFieldName]
+		if reportAs != "" {
+			errorFieldName = reportAs
+		}
+		for _, message := range errorMessages {
+			fieldError := FieldError{
+				Field:   errorFieldName,
+				Rule:    rule.Name,
+				Args:    rule.Args,
+				Value:   offendingValue(values, fieldName, defaultValue),
+				Message: message,
+			}
+			fieldError.Message = v.message(structField, fieldError)
+			*validationErrors = append(*validationErrors, fieldError)
+		}
+	}
+
+	return nil
+}
+
+// offendingValue is the value a rule judged: the field's input, else its
+// default, else nil.
+func offendingValue(values map[string]any, fieldName, defaultValue string) any {
+	if value, ok := values[fieldName]; ok {
+		return value
+	}
+	if defaultValue != "" {
+		return defaultValue
+	}
+	return nil
+}
+
+// resolveFieldInput returns values with the field's input, if any, available
+// under fieldName, the key rule funcs read. The input is found the way SetField
+// finds it: by env tag, by tag priority (descending into nested maps for dotted
+// keys), then by exact field name. values is returned as-is when it already
+// holds fieldName or nothing matched; otherwise a shallow copy is returned so
+// the caller's map is never mutated.
+func resolveFieldInput(named Field, fieldName string, values map[string]any, tagPriority []string) map[string]any {
+	if _, ok := values[fieldName]; ok {
+		return values
+	}
+
+	_, value, found := findFieldInput(named, values, tagPriority)
+	if !found {
+		return values
+	}
+
+	valuesCopy := make(map[string]any, len(values)+1)
+	for k, v := range values {
+		valuesCopy[k] = v
+	}
+	valuesCopy[fieldName] = value
+
+	return valuesCopy
+}
+
+// findFieldInput looks up the input for named (a field or its FQN view) in
+// values: by env tag first, then by each tag in tagPriority, then by Name. It
+// returns the key that matched, which for a nested map section is the dotted
+// path into it.
+func findFieldInput(named Field, values map[string]any, tagPriority []string) (string, any, bool) {
+	if envKey, ok := named.Tags[envValueTag]; ok {
+		if value, ok := values[envKey]; ok {
+			return envKey, value, true
+		}
+	}
+
+	for _, tag := range tagPriority {
+		key, ok := named.Tags[tag]
+		if !ok || key == "" {
+			continue
+		}
+		if value, ok := values[key]; ok {
+			return key, value, true
+		}
+		if path := strings.Split(key, "."); len(path) > 1 {
+			if found, value := findNestedValue(values, path); found {
+				return key, value, true
+			}
+		}
+	}
+
+	if value, ok := values[named.Name]; ok {
+		return named.Name, value, true
+	}
+
+	return "", nil, false
 }
 
 func getTagByPriority(tags map[string]string, priority []string) string {
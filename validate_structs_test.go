@@ -2,6 +2,7 @@ package structs
 
 import (
 	"testing"
+	"time"
 )
 
 type TestStructWithRules struct {
@@ -58,6 +59,182 @@ func Test_Validate_OneOf(t *testing.T) {
 	}
 }
 
+type TestStructWithMeasures struct {
+	Port    int               `json:"port" rules:"between:1,65535"`
+	Ratio   float64           `json:"ratio" rules:"max:1"`
+	Name    string            `json:"name" rules:"min:2|max:5"`
+	Country string            `json:"country" rules:"len:2"`
+	Tags    []string          `json:"tags" rules:"min:1"`
+	Labels  map[string]string `json:"labels" rules:"max:2"`
+	Retries *uint8            `json:"retries" default:"3" rules:"max:5"`
+	Hosts   []string          `json:"hosts" sep:";" rules:"min:2"`
+	Env     map[string]string `json:"env" sep:";" rules:"max:1"`
+}
+
+func Test_Validate_Measures(t *testing.T) {
+	tests := []struct {
+		name           string
+		structure      *TestStructWithMeasures
+		values         map[string]any
+		expectedErrors map[string][]string
+	}{
+		{
+			name:           "nothing to measure passes",
+			structure:      &TestStructWithMeasures{},
+			values:         map[string]any{},
+			expectedErrors: map[string][]string{},
+		},
+		{
+			name:      "inputs in range pass",
+			structure: &TestStructWithMeasures{},
+			values: map[string]any{
+				"port": "8080", "ratio": 0.5, "name": "héllo", "country": "NL",
+				"tags": []any{"a"}, "labels": map[string]any{"a": "1"}, "retries": 5,
+			},
+			expectedErrors: map[string][]string{},
+		},
+		{
+			name:      "inputs out of range fail by kind",
+			structure: &TestStructWithMeasures{},
+			values: map[string]any{
+				"port": 70000, "ratio": "1.5", "name": "x", "country": "NLD",
+				"tags": []string{}, "labels": map[string]any{"a": "1", "b": "2", "c": "3"}, "retries": uint8(9),
+			},
+			expectedErrors: map[string][]string{
+				"port":    {"must be between 1 and 65535"},
+				"ratio":   {"must be at most 1"},
+				"name":    {"must be at least 2 characters long"},
+				"country": {"must be exactly 2 characters long"},
+				"tags":    {"must have at least 1 item"},
+				"labels":  {"must have at most 2 items"},
+				"retries": {"must be at most 5"},
+			},
+		},
+		{
+			name:           "flat slice input is counted by separator",
+			structure:      &TestStructWithMeasures{},
+			values:         map[string]any{"name": "abcdef", "tags": "a,b"},
+			expectedErrors: map[string][]string{"name": {"must be at most 5 characters long"}},
+		},
+		{
+			name:           "flat input is counted by the field's sep",
+			structure:      &TestStructWithMeasures{},
+			values:         map[string]any{"hosts": "a;b", "env": "a=1;b=2"},
+			expectedErrors: map[string][]string{"env": {"must have at most 1 item"}},
+		},
+		{
+			name:           "non-numeric input for a number fails",
+			structure:      &TestStructWithMeasures{},
+			values:         map[string]any{"port": "http"},
+			expectedErrors: map[string][]string{"port": {"must be a number"}},
+		},
+		{
+			name: "already-set field values are measured",
+			structure: &TestStructWithMeasures{
+				Port:   0,
+				Ratio:  2,
+				Name:   "abcdefgh",
+				Tags:   []string{"a"},
+				Labels: map[string]string{"a": "1", "b": "2", "c": "3"},
+			},
+			values: map[string]any{},
+			expectedErrors: map[string][]string{
+				"ratio":  {"must be at most 1"},
+				"name":   {"must be at most 5 characters long"},
+				"labels": {"must have at most 2 items"},
+			},
+		},
+		{
+			name:           "inputs win over set values",
+			structure:      &TestStructWithMeasures{Name: "abcdefgh"},
+			values:         map[string]any{"name": "abc"},
+			expectedErrors: map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := GetStructFields(tt.structure, nil, DefaultEncodingTags)
+			requireNoError(t, err)
+			errors, err := ValidateStructFields(DefaultRules, fields, tt.values, "json", "json")
+			requireNoError(t, err)
+			requireEqual(t, tt.expectedErrors, errors)
+		})
+	}
+}
+
+func Test_Validate_MeasureDurations(t *testing.T) {
+	type target struct {
+		Retry   time.Duration `json:"retry" rules:"min:1"`
+		Timeout time.Duration `json:"timeout" rules:"between:1s,1h"`
+	}
+	tests := []struct {
+		name           string
+		values         map[string]any
+		expectedErrors map[string][]string
+	}{
+		{
+			name:           "duration strings are measured",
+			values:         map[string]any{"retry": "30s", "timeout": "5m"},
+			expectedErrors: map[string][]string{},
+		},
+		{
+			name:   "durations out of range fail",
+			values: map[string]any{"retry": "0s", "timeout": "2h"},
+			expectedErrors: map[string][]string{
+				"retry":   {"must be at least 1"},
+				"timeout": {"must be between 1s and 1h"},
+			},
+		},
+		{
+			name:           "a bad duration fails",
+			values:         map[string]any{"timeout": "soon"},
+			expectedErrors: map[string][]string{"timeout": {"must be a duration"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := GetStructFields(&target{}, nil, DefaultEncodingTags)
+			requireNoError(t, err)
+			errors, err := ValidateStructFields(DefaultRules, fields, tt.values, "json", "json")
+			requireNoError(t, err)
+			requireEqual(t, tt.expectedErrors, errors)
+		})
+	}
+}
+
+func Test_Validate_MeasureRuleErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		structure any
+	}{
+		{name: "missing arg", structure: &struct {
+			Name string `json:"name" rules:"min"`
+		}{Name: "x"}},
+		{name: "non-numeric arg", structure: &struct {
+			Name string `json:"name" rules:"max:ten"`
+		}{Name: "x"}},
+		{name: "between needs two args", structure: &struct {
+			Port int `json:"port" rules:"between:1"`
+		}{Port: 1}},
+		{name: "unmeasurable kind", structure: &struct {
+			Debug bool `json:"debug" rules:"min:1"`
+		}{Debug: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := GetStructFields(tt.structure, nil, DefaultEncodingTags)
+			requireNoError(t, err)
+			_, err = ValidateStructFields(DefaultRules, fields, map[string]any{}, "json", "json")
+			if err == nil {
+				t.Fatalf("expected a rule error")
+			}
+		})
+	}
+}
+
 func Test_Validate_StructFields(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -118,3 +295,125 @@ func Test_Validate_StructFields(t *testing.T) {
 		})
 	}
 }
+
+type validateNestedDatabase struct {
+	DSN  string `json:"dsn" env:"DSN" rules:"required"`
+	Mode string `json:"mode" rules:"oneof:rw,ro"`
+}
+
+type validateNestedServer struct {
+	Host     string                 `json:"host" rules:"required"`
+	Database validateNestedDatabase `json:"database" env:"DATABASE"`
+}
+
+func Test_Validate_NestedStructFields(t *testing.T) {
+	tests := []struct {
+		name           string
+		values         map[string]any
+		expectedErrors map[string][]string
+	}{
+		{
+			name:   "missing nested field is reported under its dotted path",
+			values: map[string]any{"host": "localhost"},
+			expectedErrors: map[string][]string{
+				"database.dsn": {"required"},
+			},
+		},
+		{
+			name:           "nested field by dotted key",
+			values:         map[string]any{"host": "localhost", "database.dsn": "postgres://"},
+			expectedErrors: map[string][]string{},
+		},
+		{
+			name: "nested field by nested map",
+			values: map[string]any{
+				"host":     "localhost",
+				"database": map[string]any{"dsn": "postgres://", "mode": "rw"},
+			},
+			expectedErrors: map[string][]string{},
+		},
+		{
+			name:           "nested field by glued env key",
+			values:         map[string]any{"host": "localhost", "DATABASE_DSN": "postgres://"},
+			expectedErrors: map[string][]string{},
+		},
+		{
+			name: "nested rule failure found through a nested map",
+			values: map[string]any{
+				"database": map[string]any{"dsn": "postgres://", "mode": "wo"},
+			},
+			expectedErrors: map[string][]string{
+				"host":          {"required"},
+				"database.mode": {"must be one of: rw, ro"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := GetStructFields(&validateNestedServer{}, nil, DefaultEncodingTags)
+			requireNoError(t, err)
+			errors, err := ValidateStructFields(DefaultRules, fields, tt.values, "rules", "json")
+			requireNoError(t, err)
+			requireEqual(t, tt.expectedErrors, errors)
+		})
+	}
+}
+
+func Test_ValidateStructFieldErrors(t *testing.T) {
+	fields, err := GetStructFields(&validateNestedServer{}, nil, DefaultEncodingTags)
+	requireNoError(t, err)
+
+	errs, err := ValidateStructFieldErrors(DefaultRules, fields, map[string]any{"database.mode": "wo"}, "rules", "json")
+	requireNoError(t, err)
+	requireEqual(t, ValidationErrors{
+		{Field: "host", Rule: "required", Message: "required"},
+		{Field: "database.dsn", Rule: "required", Message: "required"},
+		{Field: "database.mode", Rule: "oneof", Args: []string{"rw", "ro"}, Value: "wo", Message: "must be one of: rw, ro"},
+	}, errs)
+
+	requireEqual(t, "host: required; database.dsn: required; database.mode: must be one of: rw, ro", errs.Error())
+	requireEqual(t, map[string][]string{
+		"host":          {"required"},
+		"database.dsn":  {"required"},
+		"database.mode": {"must be one of: rw, ro"},
+	}, errs.Map())
+
+	data, err := errs[2:].JSON()
+	requireNoError(t, err)
+	requireEqual(t, `[{"field":"database.mode","rule":"oneof","args":["rw","ro"],"value":"wo","message":"must be one of: rw, ro"}]`, string(data))
+
+	requireEqual(t, map[string][]string{}, ValidationErrors{}.Map())
+}
+
+type orderedConfig struct {
+	Name     string                 `json:"name" rules:"required|min:3"`
+	Mode     string                 `json:"mode" rules:"oneof:dev,prod"`
+	Database validateNestedDatabase `json:"database"`
+	Port     int                    `json:"port" rules:"required|between:1,65535"`
+	Confirm  string                 `json:"confirm" rules:"eqfield:Name|min:3"`
+}
+
+func Test_ValidationErrors_Ordered(t *testing.T) {
+	values := map[string]any{"name": "x", "mode": "test", "port": 0, "confirm": "y", "database": map[string]any{"mode": "wo"}}
+	expected := []FieldMessages{
+		{Field: "name", Messages: []string{"must be at least 3 characters long"}},
+		{Field: "mode", Messages: []string{"must be one of: dev, prod"}},
+		{Field: "database.dsn", Messages: []string{"required"}},
+		{Field: "database.mode", Messages: []string{"must be one of: rw, ro"}},
+		{Field: "port", Messages: []string{"must be between 1 and 65535"}},
+		{Field: "confirm", Messages: []string{"must equal Name", "must be at least 3 characters long"}},
+	}
+
+	fields, err := GetStructFields(&orderedConfig{}, nil, DefaultEncodingTags)
+	requireNoError(t, err)
+
+	// map iteration order varies between runs; the ordered view must not
+	for range 20 {
+		errs, err := ValidateStructFieldErrors(DefaultRules, fields, values, "rules", "json")
+		requireNoError(t, err)
+		requireEqual(t, expected, errs.Ordered())
+	}
+
+	requireEqual(t, []FieldMessages{}, ValidationErrors{}.Ordered())
+}
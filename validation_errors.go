@@ -0,0 +1,82 @@
+package structs
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// FieldError is one failed validation rule on one field.
+type FieldError struct {
+	// Field is the name the failure is reported under: the field's key by tag
+	// priority (dotted for nested fields, e.g. "database.dsn"), or its
+	// validation tag value.
+	Field string `json:"field"`
+	// Rule is the failed rule's name, e.g. "required" or "oneof".
+	Rule string `json:"rule"`
+	// Args are the rule's args, e.g. ["json", "yaml"] for oneof:json,yaml.
+	Args []string `json:"args,omitempty"`
+	// Value is the input the rule judged (or the default it fell back to),
+	// nil when there was none.
+	Value any `json:"value"`
+	// Message is the human-readable failure, e.g. "required".
+	Message string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationErrors is every failed rule, in the order they were found:
+// fields in declaration order, rules in `rules:` tag order. It implements
+// error, so it can be returned and unwrapped with errors.As, and marshals to a
+// JSON array of FieldError objects for machine-readable API responses.
+type ValidationErrors []FieldError
+
+// Error joins the failures as "field: message" pairs.
+func (e ValidationErrors) Error() string {
+	parts := make([]string, 0, len(e))
+	for _, fieldError := range e {
+		parts = append(parts, fieldError.Error())
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Map converts the errors to the field name to messages shape returned by
+// Validate and ValidateStructFields.
+func (e ValidationErrors) Map() map[string][]string {
+	errors := make(map[string][]string)
+	for _, fieldError := range e {
+		errors[fieldError.Field] = append(errors[fieldError.Field], fieldError.Message)
+	}
+	return errors
+}
+
+// FieldMessages is one field's failure messages, see ValidationErrors.Ordered.
+type FieldMessages struct {
+	Field    string   `json:"field"`
+	Messages []string `json:"messages"`
+}
+
+// Ordered is Map as a slice: one entry per failing field, in the order the
+// fields failed (declaration order, nested fields after their parent's own
+// rules), with each field's messages in rule order. Unlike ranging over Map,
+// iterating it prints the same output every run.
+func (e ValidationErrors) Ordered() []FieldMessages {
+	ordered := make([]FieldMessages, 0)
+	index := make(map[string]int)
+	for _, fieldError := range e {
+		i, ok := index[fieldError.Field]
+		if !ok {
+			i = len(ordered)
+			index[fieldError.Field] = i
+			ordered = append(ordered, FieldMessages{Field: fieldError.Field})
+		}
+		ordered[i].Messages = append(ordered[i].Messages, fieldError.Message)
+	}
+	return ordered
+}
+
+// JSON marshals the errors to a JSON array of FieldError objects.
+func (e ValidationErrors) JSON() ([]byte, error) {
+	return json.Marshal([]FieldError(e))
+}